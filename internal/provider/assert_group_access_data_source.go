@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// noPermission is the name used in assertions for a group without access.
+const noPermission = "none"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &AssertGroupAccessDataSource{}
+	_ datasource.DataSourceWithConfigure = &AssertGroupAccessDataSource{}
+)
+
+// NewAssertGroupAccessDataSource is a helper function to simplify the provider implementation.
+func NewAssertGroupAccessDataSource() datasource.DataSource {
+	return &AssertGroupAccessDataSource{}
+}
+
+// AssertGroupAccessDataSource is the data source implementation.
+type AssertGroupAccessDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// AssertGroupAccessDataSourceModel describes the data source data model.
+type AssertGroupAccessDataSourceModel struct {
+	ResourceID         types.String `tfsdk:"resource_id"`
+	FolderID           types.String `tfsdk:"folder_id"`
+	Group              types.String `tfsdk:"group"`
+	ExpectedPermission types.String `tfsdk:"expected_permission"`
+	AtLeast            types.Bool   `tfsdk:"at_least"`
+	ActualPermission   types.String `tfsdk:"actual_permission"`
+	Passed             types.Bool   `tfsdk:"passed"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *AssertGroupAccessDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *AssertGroupAccessDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assert_group_access"
+}
+
+// Schema defines the schema for the data source.
+func (d *AssertGroupAccessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Whether a group has the expected permission on a password resource or folder, meant to be used in check blocks",
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The unique identifier of the password resource, exactly one of resource_id and folder_id must be set",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("folder_id")),
+				},
+			},
+			"folder_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The unique identifier of the folder, exactly one of resource_id and folder_id must be set",
+			},
+			"group": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the group",
+			},
+			"expected_permission": schema.StringAttribute{
+				Required:    true,
+				Description: "The permission the group is expected to have, one of none, read, update or owner",
+				Validators: []validator.String{
+					stringvalidator.OneOf(append([]string{noPermission}, permissionNames()...)...),
+				},
+			},
+			"at_least": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also pass when the group has a higher permission than expected (default false, the permission must match exactly)",
+			},
+			"actual_permission": schema.StringAttribute{
+				Computed:    true,
+				Description: "The permission the group has, none when it has no access",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the permission of the group matches the expected permission",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *AssertGroupAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_assert_group_access", &resp.Diagnostics) {
+		return
+	}
+
+	var state AssertGroupAccessDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A misspelled group would otherwise silently assert that it has no access
+	groupIDs, err := getGroupIDs(ctx, d.data.Lookups)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot get groups", err.Error())
+		return
+	}
+	groupID, ok := groupIDs[state.Group.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("group"), "Group not found", fmt.Sprintf("Group '%s' not found", state.Group.ValueString()))
+		return
+	}
+
+	permissions, err := getPermissionsWithARO(ctx, d.client, state.ResourceID.ValueString(), state.FolderID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permissions",
+			"Could not read permissions, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	actual := 0
+	for _, permission := range permissions {
+		if permission.ARO == "Group" && permission.AROForeignKey == groupID {
+			actual = permission.Type
+		}
+	}
+	expected := permissionTypes[state.ExpectedPermission.ValueString()]
+
+	state.ActualPermission = types.StringValue(noPermission)
+	if actual != 0 {
+		state.ActualPermission = types.StringValue(permissionName(actual))
+	}
+	state.Passed = types.BoolValue(actual == expected || state.AtLeast.ValueBool() && actual > expected)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Plugins of the Passbolt server required by some resources and data sources.
+const (
+	pluginFolders        = "folders"
+	pluginTags           = "tags"
+	pluginTOTP           = "totpResourceTypes"
+	pluginPasswordExpiry = "passwordExpiry"
+	pluginMetadata       = "metadata"
+)
+
+// ServerCapabilities describes the edition and the enabled plugins of the Passbolt server.
+type ServerCapabilities struct {
+	Edition string
+	Version string
+	Plugins map[string]bool
+}
+
+// serverSettings is the part of the settings endpoint response describing the server capabilities.
+type serverSettings struct {
+	App struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	} `json:"app"`
+	Passbolt struct {
+		Edition string                     `json:"edition"`
+		Plugins map[string]json.RawMessage `json:"plugins"`
+	} `json:"passbolt"`
+}
+
+// getCapabilities reads the capabilities of the server from its settings.
+func getCapabilities(ctx context.Context, client *api.Client) (*ServerCapabilities, error) {
+	msg, err := doCustomRequest(ctx, client, "GET", "/settings.json", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings serverSettings
+	err = json.Unmarshal(msg.Body, &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &ServerCapabilities{
+		Edition: settings.Passbolt.Edition,
+		Version: settings.App.Version.Number,
+		Plugins: make(map[string]bool, len(settings.Passbolt.Plugins)),
+	}
+	for name, raw := range settings.Passbolt.Plugins {
+		// Plugins are listed when installed, some of them also report whether they are enabled
+		var plugin struct {
+			Enabled *bool `json:"enabled"`
+		}
+		_ = json.Unmarshal(raw, &plugin)
+		capabilities.Plugins[name] = plugin.Enabled == nil || *plugin.Enabled
+	}
+	return capabilities, nil
+}
+
+// HasPlugin checks if a plugin is enabled on the server.
+// Capabilities that could not be detected are assumed to be available.
+func (c *ServerCapabilities) HasPlugin(name string) bool {
+	if c == nil {
+		return true
+	}
+	return c.Plugins[name]
+}
+
+// requirePlugin adds an error if a plugin needed by a resource or data source is not enabled on the server.
+func requirePlugin(data *PassboltProviderData, name, typeName string, diags *diag.Diagnostics) bool {
+	if data == nil || data.Capabilities.HasPlugin(name) {
+		return true
+	}
+
+	diags.AddError(
+		"Passbolt plugin not available",
+		fmt.Sprintf("The %s plugin is disabled on %s, %s cannot be used. "+
+			"Enable the plugin on the server or remove %s from the configuration.",
+			name, serverName(data.Capabilities), typeName, typeName),
+	)
+	return false
+}
+
+// serverName describes the server in messages, with its edition when it is known.
+func serverName(capabilities *ServerCapabilities) string {
+	if capabilities == nil || capabilities.Edition == "" {
+		return "this server"
+	}
+	return "this " + editionName(capabilities.Edition) + " server"
+}
+
+// editionName returns the display name of a Passbolt edition.
+func editionName(edition string) string {
+	switch edition {
+	case "ce":
+		return "CE"
+	case "pro":
+		return "Pro"
+	default:
+		return edition
+	}
+}
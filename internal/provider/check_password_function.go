@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &CheckPasswordFunction{}
+
+// NewCheckPasswordFunction is a helper function to simplify the provider implementation.
+func NewCheckPasswordFunction() function.Function {
+	return &CheckPasswordFunction{}
+}
+
+// CheckPasswordFunction is the function implementation.
+type CheckPasswordFunction struct{}
+
+// passwordPolicy is the policy a password is checked against, the zero value accepts any password.
+type passwordPolicy struct {
+	MinLength        int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigits    bool
+	RequireSymbols   bool
+	MinEntropy       float64
+}
+
+// Metadata returns the function name.
+func (f *CheckPasswordFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "check_password"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *CheckPasswordFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check a password against a policy",
+		Description: "Returns whether the password satisfies the policy. The policy is a map with the optional keys min_length, " +
+			"require_lowercase, require_uppercase, require_digits, require_symbols and min_entropy, the entropy is estimated in bits " +
+			"from the length and the character classes used.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "password",
+				Description: "The password to check",
+			},
+			function.MapParameter{
+				Name:        "policy",
+				ElementType: types.StringType,
+				Description: "The policy to check the password against (e.g., { min_length = 16, require_symbols = true })",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run checks the password against the policy.
+func (f *CheckPasswordFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var password string
+	var settings map[string]string
+	resp.Error = req.Arguments.Get(ctx, &password, &settings)
+	if resp.Error != nil {
+		return
+	}
+
+	policy, err := parsePasswordPolicy(settings)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, policy.allows(password))
+}
+
+// parsePasswordPolicy reads a policy from its map representation.
+func parsePasswordPolicy(settings map[string]string) (passwordPolicy, error) {
+	var policy passwordPolicy
+	flags := map[string]*bool{
+		"require_lowercase": &policy.RequireLowercase,
+		"require_uppercase": &policy.RequireUppercase,
+		"require_digits":    &policy.RequireDigits,
+		"require_symbols":   &policy.RequireSymbols,
+	}
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := settings[key]
+		var err error
+		switch key {
+		case "min_length":
+			policy.MinLength, err = strconv.Atoi(value)
+			if err == nil && policy.MinLength < 0 {
+				err = fmt.Errorf("cannot be negative")
+			}
+		case "min_entropy":
+			policy.MinEntropy, err = strconv.ParseFloat(value, 64)
+			if err == nil && policy.MinEntropy < 0 {
+				err = fmt.Errorf("cannot be negative")
+			}
+		default:
+			flag, ok := flags[key]
+			if !ok {
+				return policy, fmt.Errorf("unknown policy key %q", key)
+			}
+			*flag, err = strconv.ParseBool(value)
+		}
+		if err != nil {
+			return policy, fmt.Errorf("invalid policy value for %s: %s", key, err.Error())
+		}
+	}
+	return policy, nil
+}
+
+// allows returns whether the password satisfies the policy.
+func (p passwordPolicy) allows(password string) bool {
+	var lower, upper, digit, symbol bool
+	length := 0
+	for _, c := range password {
+		length++
+		switch {
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	if length < p.MinLength ||
+		p.RequireLowercase && !lower ||
+		p.RequireUppercase && !upper ||
+		p.RequireDigits && !digit ||
+		p.RequireSymbols && !symbol {
+		return false
+	}
+
+	// Estimate the entropy as if every character was picked at random from the classes used
+	pool := 0
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if symbol {
+		pool += len(passwordSymbols)
+	}
+
+	entropy := 0.0
+	if pool > 0 {
+		entropy = float64(length) * math.Log2(float64(pool))
+	}
+	return entropy >= p.MinEntropy
+}
+
+// passwordSymbols are the printable ASCII symbols, used to size the symbol class in entropy estimates.
+const passwordSymbols = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~ "
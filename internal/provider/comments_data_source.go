@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &CommentsDataSource{}
+	_ datasource.DataSourceWithConfigure = &CommentsDataSource{}
+)
+
+// NewCommentsDataSource is a helper function to simplify the provider implementation.
+func NewCommentsDataSource() datasource.DataSource {
+	return &CommentsDataSource{}
+}
+
+// CommentsDataSource is the data source implementation.
+type CommentsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// CommentsDataSourceModel describes the data source data model.
+type CommentsDataSourceModel struct {
+	ResourceID types.String   `tfsdk:"resource_id"`
+	Comments   []CommentModel `tfsdk:"comments"`
+}
+
+// CommentModel describes a single comment on a password resource.
+type CommentModel struct {
+	ID       types.String `tfsdk:"id"`
+	ParentID types.String `tfsdk:"parent_id"`
+	Content  types.String `tfsdk:"content"`
+	Author   types.String `tfsdk:"author"`
+	AuthorID types.String `tfsdk:"author_id"`
+	Created  types.String `tfsdk:"created"`
+	Modified types.String `tfsdk:"modified"`
+}
+
+// commentWithCreator is a comment including the user who wrote it.
+type commentWithCreator struct {
+	api.Comment
+	Creator  *api.User            `json:"creator,omitempty"`
+	Children []commentWithCreator `json:"children,omitempty"`
+}
+
+// getCommentsOptions are the query parameters of the comments index.
+type getCommentsOptions struct {
+	ContainCreator bool `url:"contain[creator],omitempty"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *CommentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *CommentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_comments"
+}
+
+// Schema defines the schema for the data source.
+func (d *CommentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the password resource",
+			},
+			"comments": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of comments on the password resource, oldest first with replies following their parent",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the comment",
+						},
+						"parent_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the comment this comment replies to, null for top level comments",
+						},
+						"content": schema.StringAttribute{
+							Computed:    true,
+							Description: "The text of the comment",
+						},
+						"author": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username of the user who wrote the comment",
+						},
+						"author_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the user who wrote the comment",
+						},
+						"created": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the comment was written",
+						},
+						"modified": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the comment was last modified",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CommentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_comments", &resp.Diagnostics) {
+		return
+	}
+
+	var state CommentsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/comments/resource/"+state.ResourceID.ValueString()+".json", nil, getCommentsOptions{
+		ContainCreator: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading comments",
+			"Could not read comments, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var comments []commentWithCreator
+	err = json.Unmarshal(msg.Body, &comments)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading comments",
+			"Could not decode comments, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.Comments = make([]CommentModel, 0)
+	appendComments(&state.Comments, comments)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// appendComments flattens a thread of comments oldest first, each comment followed by its replies.
+func appendComments(models *[]CommentModel, comments []commentWithCreator) {
+	sorted := append([]commentWithCreator(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Created == nil || sorted[j].Created == nil {
+			return sorted[j].Created != nil
+		}
+		return sorted[i].Created.Before(sorted[j].Created.Time)
+	})
+
+	for _, comment := range sorted {
+		model := CommentModel{
+			ID:       types.StringValue(comment.ID),
+			ParentID: types.StringNull(),
+			Content:  types.StringValue(comment.Content),
+			Author:   types.StringNull(),
+			AuthorID: types.StringValue(comment.CreatedBy),
+			Created:  types.StringNull(),
+			Modified: types.StringNull(),
+		}
+		if comment.ParentID != "" {
+			model.ParentID = types.StringValue(comment.ParentID)
+		}
+		if comment.Creator != nil {
+			model.Author = types.StringValue(comment.Creator.Username)
+		}
+		if comment.Created != nil {
+			model.Created = types.StringValue(comment.Created.UTC().Format(time.RFC3339))
+		}
+		if comment.Modified != nil {
+			model.Modified = types.StringValue(comment.Modified.UTC().Format(time.RFC3339))
+		}
+
+		*models = append(*models, model)
+		appendComments(models, comment.Children)
+	}
+}
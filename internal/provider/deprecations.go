@@ -0,0 +1,13 @@
+package provider
+
+import "fmt"
+
+// deprecatedAttributeMessage returns the deprecation message of an attribute replaced by another one.
+// The deprecated attribute keeps working and is mapped onto its replacement until it is removed.
+func deprecatedAttributeMessage(attribute, replacement string) string {
+	return fmt.Sprintf(
+		"The %s attribute is deprecated and will be removed in a future major version, use %s instead. "+
+			"Until then %s is mapped onto %s, both cannot be set at the same time.",
+		attribute, replacement, attribute, replacement,
+	)
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// errorClass is the kind of failure of a Passbolt API call, it decides how the failure is handled.
+type errorClass int
+
+const (
+	// errorUnknown failures are reported as they are.
+	errorUnknown errorClass = iota
+	// errorNotFound failures remove the object from the state when it is read, it was deleted outside of Terraform.
+	errorNotFound
+	// errorPermissionDenied failures are reported, the authenticated user lacks a permission.
+	errorPermissionDenied
+	// errorValidation failures are reported, the server rejected the submitted values.
+	errorValidation
+	// errorRateLimited failures are retried by the transport, they are reported once the retries are exhausted.
+	errorRateLimited
+	// errorAuthExpired failures log in again before the read is retried once.
+	errorAuthExpired
+)
+
+// errorStatusClasses are the classes of the HTTP status codes reported by the Passbolt API.
+var errorStatusClasses = map[int]errorClass{
+	http.StatusBadRequest:      errorValidation,
+	http.StatusUnauthorized:    errorAuthExpired,
+	http.StatusForbidden:       errorPermissionDenied,
+	http.StatusNotFound:        errorNotFound,
+	http.StatusTooManyRequests: errorRateLimited,
+}
+
+// errorPatterns are the parts of the header messages of each class, matched case insensitively in this order.
+// Only the header message is matched: the body following it in the errors of go-passbolt can contain anything,
+// such as IDs or the validation messages of related objects.
+var errorPatterns = []struct {
+	class    errorClass
+	patterns []string
+}{
+	{errorValidation, []string{"could not validate", "validation"}},
+	{errorNotFound, []string{"does not exist", "not found"}},
+	{errorAuthExpired, []string{"authentication is required", "not authenticated", "session expired"}},
+	{errorPermissionDenied, []string{"not authorized", "not allowed", "access denied", "permission denied", "forbidden"}},
+	{errorRateLimited, []string{"too many requests", "rate limit"}},
+}
+
+// statusCodePattern matches the error go-passbolt returns for responses that are not JSON, such as the error pages of
+// a proxy, the only errors of go-passbolt including the HTTP status code.
+var statusCodePattern = regexp.MustCompile(`Unable to Parse JSON API Response with HTTP Status Code (\d+)`)
+
+// errorHints are the explanations appended to the errors of each class.
+var errorHints = map[errorClass]string{
+	errorPermissionDenied: "The authenticated user lacks the permission for this operation. Check its role and its permissions on the object.",
+	errorValidation:       "The Passbolt server rejected the submitted values. Check the attribute values against the constraints of the server.",
+	errorRateLimited:      "The Passbolt server is still throttling requests after the retries. Increase max_retries or max_elapsed_time, or reduce the parallelism of Terraform.",
+	errorAuthExpired:      "The session of the authenticated user expired and logging in again did not help. Check that the user and its key are still active.",
+}
+
+// apiError is the error of a Passbolt API call made with doCustomRequest, along with the header of the response.
+type apiError struct {
+	header api.APIHeader
+	err    error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// withHeader attaches the header of the response returned by DoCustomRequest to its error,
+// so that the error is classified by its status code rather than by its message.
+func withHeader(msg *api.APIResponse, err error) error {
+	if err == nil || msg == nil || msg.Header.Code == 0 {
+		return err
+	}
+	return &apiError{header: msg.Header, err: err}
+}
+
+// doCustomRequest makes a request to the v2 Passbolt API, its error carries the header of the response.
+func doCustomRequest(ctx context.Context, client *api.Client, method, path string, body interface{}, opts interface{}) (*api.APIResponse, error) {
+	msg, err := client.DoCustomRequest(ctx, method, path, "v2", body, opts)
+	return msg, withHeader(msg, err)
+}
+
+// classifyError returns the class of an error returned by a Passbolt API call. The status code of the response decides
+// when it is known, the header message of the response otherwise, go-passbolt only reports the code of responses that
+// are not JSON.
+func classifyError(err error) errorClass {
+	if err == nil {
+		return errorUnknown
+	}
+
+	var withCode *apiError
+	if errors.As(err, &withCode) {
+		return errorStatusClasses[withCode.header.Code]
+	}
+
+	if message, ok := headerMessage(err.Error()); ok {
+		return classifyMessage(message)
+	}
+
+	if match := statusCodePattern.FindStringSubmatch(err.Error()); match != nil {
+		code, _ := strconv.Atoi(match[1])
+		return errorStatusClasses[code]
+	}
+	return errorUnknown
+}
+
+// headerMessage extracts the header message from the error go-passbolt returns for an error response,
+// formatted as "<status>: Message: <message>, Body: <body>".
+func headerMessage(text string) (string, bool) {
+	for _, status := range []error{api.ErrAPIResponseErrorStatusCode, api.ErrAPIResponseUnknownStatusCode} {
+		prefix := status.Error() + ": Message: "
+		start := strings.Index(text, prefix)
+		if start < 0 {
+			continue
+		}
+
+		message := text[start+len(prefix):]
+		if end := strings.Index(message, ", Body: "); end >= 0 {
+			message = message[:end]
+		}
+		return message, true
+	}
+	return "", false
+}
+
+// classifyMessage returns the class of the header message of an error response.
+func classifyMessage(message string) errorClass {
+	message = strings.ToLower(message)
+	for _, class := range errorPatterns {
+		for _, pattern := range class.patterns {
+			if strings.Contains(message, pattern) {
+				return class.class
+			}
+		}
+	}
+	return errorUnknown
+}
+
+// isResourceNotFoundError checks if the error indicates that the resource doesn't exist
+func isResourceNotFoundError(err error) bool {
+	return classifyError(err) == errorNotFound
+}
+
+// describeError returns the message of an error followed by an explanation of its class, if any.
+func describeError(err error) string {
+	hint, ok := errorHints[classifyError(err)]
+	if !ok {
+		return err.Error()
+	}
+	return err.Error() + "\n\n" + hint
+}
+
+// reauthenticate logs in again when an error shows that the session expired, and reports whether the failed request
+// should be retried. Concurrent operations hitting the expired session log in one after the other.
+func (d *PassboltProviderData) reauthenticate(ctx context.Context, err error) bool {
+	if classifyError(err) != errorAuthExpired {
+		return false
+	}
+
+	d.loginMu.Lock()
+	defer d.loginMu.Unlock()
+
+	return login(ctx, d.Client, d.LoginRetry) == nil
+}
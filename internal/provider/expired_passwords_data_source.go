@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ExpiredPasswordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ExpiredPasswordsDataSource{}
+)
+
+// NewExpiredPasswordsDataSource is a helper function to simplify the provider implementation.
+func NewExpiredPasswordsDataSource() datasource.DataSource {
+	return &ExpiredPasswordsDataSource{}
+}
+
+// ExpiredPasswordsDataSource is the data source implementation.
+type ExpiredPasswordsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// ExpiredPasswordsDataSourceModel describes the data source data model.
+type ExpiredPasswordsDataSourceModel struct {
+	WithinDays types.Int64            `tfsdk:"within_days"`
+	Passwords  []ExpiredPasswordModel `tfsdk:"passwords"`
+}
+
+// ExpiredPasswordModel describes a single expired password resource.
+type ExpiredPasswordModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Username types.String `tfsdk:"username"`
+	URI      types.String `tfsdk:"uri"`
+	Expired  types.String `tfsdk:"expired"`
+}
+
+// resourceWithExpiry is a resource including its expiry date, which api.Resource does not decode.
+type resourceWithExpiry struct {
+	api.Resource
+	Expired *api.Time `json:"expired,omitempty"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ExpiredPasswordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *ExpiredPasswordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_expired_passwords"
+}
+
+// Schema defines the schema for the data source.
+func (d *ExpiredPasswordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"within_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Also return the password resources expiring within this number of days (default 0, only already expired resources)",
+			},
+			"passwords": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of expired password resources, sorted by expiry date",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the password resource",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the password resource",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username for the password resource",
+						},
+						"uri": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URI for the password resource",
+						},
+						"expired": schema.StringAttribute{
+							Computed:    true,
+							Description: "The expiry date of the password resource",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ExpiredPasswordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_expired_passwords", &resp.Diagnostics) {
+		return
+	}
+
+	var state ExpiredPasswordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.WithinDays.ValueInt64() < 0 {
+		resp.Diagnostics.AddError("Validation Error", "within_days cannot be negative")
+		return
+	}
+	deadline := time.Now().Add(time.Duration(state.WithinDays.ValueInt64()) * 24 * time.Hour)
+
+	// Get all resources with their expiry date from Passbolt
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/resources.json", nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading passwords",
+			"Could not read passwords, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var resources []resourceWithExpiry
+	err = json.Unmarshal(msg.Body, &resources)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading passwords",
+			"Could not decode passwords, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Resources without an expiry date never expire
+	expired := make([]resourceWithExpiry, 0)
+	for _, resource := range resources {
+		if resource.Expired != nil && !resource.Expired.After(deadline) {
+			expired = append(expired, resource)
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		if !expired[i].Expired.Equal(expired[j].Expired.Time) {
+			return expired[i].Expired.Before(expired[j].Expired.Time)
+		}
+		return expired[i].ID < expired[j].ID
+	})
+
+	state.Passwords = make([]ExpiredPasswordModel, 0, len(expired))
+	for _, resource := range expired {
+		state.Passwords = append(state.Passwords, ExpiredPasswordModel{
+			ID:       types.StringValue(resource.ID),
+			Name:     types.StringValue(resource.Name),
+			Username: types.StringValue(resource.Username),
+			URI:      types.StringValue(resource.URI),
+			Expired:  types.StringValue(resource.Expired.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &FolderIDDataSource{}
+	_ datasource.DataSourceWithConfigure = &FolderIDDataSource{}
+)
+
+// NewFolderIDDataSource is a helper function to simplify the provider implementation.
+func NewFolderIDDataSource() datasource.DataSource {
+	return &FolderIDDataSource{}
+}
+
+// FolderIDDataSource is the data source implementation.
+type FolderIDDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// FolderIDDataSourceModel describes the data source data model.
+type FolderIDDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	FolderParentID types.String `tfsdk:"folder_parent_id"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *FolderIDDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+
+	requirePlugin(data, pluginFolders, "passbolt_folder_id", &resp.Diagnostics)
+}
+
+// Metadata returns the data source type name.
+func (d *FolderIDDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder_id"
+}
+
+// Schema defines the schema for the data source.
+func (d *FolderIDDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the folder",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The exact name of the folder",
+			},
+			"folder_parent_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The unique identifier of the parent folder, required when several folders have the same name",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *FolderIDDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_folder_id", &resp.Diagnostics) {
+		return
+	}
+
+	var state FolderIDDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Let the server narrow down the folders instead of listing all of them
+	opts := &api.GetFoldersOptions{
+		FilterSearch: state.Name.ValueString(),
+	}
+	if !state.FolderParentID.IsNull() {
+		opts.FilterHasParent = []string{state.FolderParentID.ValueString()}
+	}
+
+	folders, err := d.client.GetFolders(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading folders",
+			"Could not read folders, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// The search filter also matches partial names
+	var matches []api.Folder
+	for _, folder := range folders {
+		if folder.Name == state.Name.ValueString() {
+			matches = append(matches, folder)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("Folder not found", fmt.Sprintf("Folder '%s' not found", state.Name.ValueString()))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Multiple folders found",
+			fmt.Sprintf("Found %d folders named '%s', set folder_parent_id to select one of them", len(matches), state.Name.ValueString()),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(matches[0].ID)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &FolderPathsDataSource{}
+	_ datasource.DataSourceWithConfigure = &FolderPathsDataSource{}
+)
+
+// NewFolderPathsDataSource is a helper function to simplify the provider implementation.
+func NewFolderPathsDataSource() datasource.DataSource {
+	return &FolderPathsDataSource{}
+}
+
+// FolderPathsDataSource is the data source implementation.
+type FolderPathsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// FolderPathsDataSourceModel describes the data source data model.
+type FolderPathsDataSourceModel struct {
+	Folders []FolderPathModel       `tfsdk:"folders"`
+	Paths   map[string]types.String `tfsdk:"paths"`
+}
+
+// FolderPathModel describes a single folder with its full path.
+type FolderPathModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	FolderParentID types.String `tfsdk:"folder_parent_id"`
+	Path           types.String `tfsdk:"path"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *FolderPathsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+
+	requirePlugin(data, pluginFolders, "passbolt_folder_paths", &resp.Diagnostics)
+}
+
+// Metadata returns the data source type name.
+func (d *FolderPathsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder_paths"
+}
+
+// Schema defines the schema for the data source.
+func (d *FolderPathsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"folders": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of all folders, sorted by path",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the folder",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the folder",
+						},
+						"folder_parent_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the parent folder",
+						},
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "The full path of the folder, such as Infra/Prod/DB",
+						},
+					},
+				},
+			},
+			"paths": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The folder IDs keyed by full path, paths shared by several folders are left out",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *FolderPathsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_folder_paths", &resp.Diagnostics) {
+		return
+	}
+
+	var state FolderPathsDataSourceModel
+
+	// Get all folders at once, the paths are resolved locally
+	folders, err := d.data.Lookups.Folders(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading folders",
+			"Could not read folders, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	paths := folderPaths(folders)
+
+	state.Folders = make([]FolderPathModel, 0, len(folders))
+	for _, folder := range folders {
+		state.Folders = append(state.Folders, FolderPathModel{
+			ID:             types.StringValue(folder.ID),
+			Name:           types.StringValue(folder.Name),
+			FolderParentID: folderIDValue(folder.FolderParentID),
+			Path:           types.StringValue(paths[folder.ID]),
+		})
+	}
+	sort.SliceStable(state.Folders, func(i, j int) bool {
+		if state.Folders[i].Path.ValueString() != state.Folders[j].Path.ValueString() {
+			return state.Folders[i].Path.ValueString() < state.Folders[j].Path.ValueString()
+		}
+		return state.Folders[i].ID.ValueString() < state.Folders[j].ID.ValueString()
+	})
+
+	// Ambiguous paths cannot be resolved to a single folder
+	counts := make(map[string]int, len(paths))
+	for _, folderPath := range paths {
+		counts[folderPath]++
+	}
+	state.Paths = make(map[string]types.String, len(paths))
+	for folderID, folderPath := range paths {
+		if counts[folderPath] == 1 {
+			state.Paths[folderPath] = types.StringValue(folderID)
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// folderPaths resolves the full path of every folder, keyed by folder ID.
+// Parents that are not visible to the user end the path.
+func folderPaths(folders []api.Folder) map[string]string {
+	byID := make(map[string]api.Folder, len(folders))
+	for _, folder := range folders {
+		byID[folder.ID] = folder
+	}
+
+	paths := make(map[string]string, len(folders))
+	for _, folder := range folders {
+		var names []string
+		visited := make(map[string]bool)
+		for current, ok := folder, true; ok && !visited[current.ID]; current, ok = byID[current.FolderParentID] {
+			visited[current.ID] = true
+			names = append([]string{current.Name}, names...)
+		}
+		paths[folder.ID] = strings.Join(names, "/")
+	}
+	return paths
+}
@@ -3,18 +3,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource              = &FolderResource{}
 	_ resource.ResourceWithConfigure = &FolderResource{}
+	_ resource.ResourceWithMoveState = &FolderResource{}
 )
 
 // NewFolderResource is a helper function to simplify the provider implementation.
@@ -25,14 +32,20 @@ func NewFolderResource() resource.Resource {
 // FolderResource is the resource implementation.
 type FolderResource struct {
 	client *api.Client
+	data   *PassboltProviderData
 }
 
 // FolderResourceModel describes the resource data model.
 type FolderResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Personal     types.Bool   `tfsdk:"personal"`
-	FolderParent types.String `tfsdk:"folder_parent"`
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	Personal      types.Bool     `tfsdk:"personal"`
+	FolderParent  types.String   `tfsdk:"folder_parent"`
+	Path          types.String   `tfsdk:"path"`
+	Shares        types.Set      `tfsdk:"shares"`
+	Propagate     types.Bool     `tfsdk:"propagate_shares"`
+	PermissionIDs types.Map      `tfsdk:"share_permission_ids"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -41,16 +54,19 @@ func (r *FolderResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*PassboltProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.data = data
+
+	requirePlugin(data, pluginFolders, "passbolt_folder", &resp.Diagnostics)
 }
 
 // Metadata returns the resource type name.
@@ -59,7 +75,7 @@ func (r *FolderResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 // Schema defines the schema for the resource.
-func (r *FolderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *FolderResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -80,12 +96,57 @@ func (r *FolderResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Description: "The name of the parent folder",
 			},
+			"path": schema.StringAttribute{
+				Computed:    true,
+				Description: "The full path of the folder, such as Infra/Prod/DB",
+			},
+			"shares": schema.SetNestedAttribute{
+				Optional:    true,
+				Description: "The groups to share the folder with, permissions of other users and groups are left untouched",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the group",
+						},
+						"permission": schema.StringAttribute{
+							Required:    true,
+							Description: "The permission granted to the group, one of read, update or owner",
+							Validators: []validator.String{
+								stringvalidator.OneOf(permissionNames()...),
+							},
+						},
+					},
+				},
+			},
+			"share_permission_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The unique identifiers of the permissions of the groups the folder is shared with, keyed by group name",
+			},
+			"propagate_shares": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether changes to shares are also applied to every password resource in the folder and its subfolders, " +
+					"as Passbolt does not apply folder permissions to their content",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_folder", &resp.Diagnostics) {
+		return
+	}
+
 	var plan FolderResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -93,6 +154,15 @@ func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Validate input
 	if plan.Name.ValueString() == "" {
 		resp.Diagnostics.AddError("Validation Error", "Name cannot be empty")
@@ -102,7 +172,7 @@ func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Get parent folder ID if specified
 	var parentFolderID string
 	if !plan.FolderParent.IsNull() && !plan.FolderParent.IsUnknown() {
-		folders, err := r.client.GetFolders(ctx, nil)
+		folders, err := r.data.Lookups.Folders(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError("Cannot get folders", err.Error())
 			return
@@ -128,17 +198,39 @@ func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	createdFolder, err := r.client.CreateFolder(ctx, folder)
+	r.data.Lookups.InvalidateFolders()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating folder",
-			"Could not create folder, unexpected error: "+err.Error(),
+			"Could not create folder, unexpected error: "+describeError(err),
 		)
 		return
 	}
 
+	// Record the folder before sharing it, a failure then leaves it in the state instead of orphaned
+	partial := plan
+	partial.ID = types.StringValue(createdFolder.ID)
+	partial.Personal = types.BoolValue(createdFolder.Personal)
+	partial.Path = types.StringNull()
+	partial.Shares = types.SetNull(plan.Shares.ElementType(ctx))
+	partial.PermissionIDs = types.MapNull(types.StringType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, partial)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new folder is empty, there is nothing to propagate the shares to yet
+	r.updateShares(ctx, createdFolder.ID, nil, plan, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		r.rollbackFailedCreate(ctx, createdFolder.ID, plan.Name.ValueString(), resp)
+		return
+	}
+
 	// Set the computed values
 	plan.ID = types.StringValue(createdFolder.ID)
 	plan.Personal = types.BoolValue(createdFolder.Personal)
+	plan.Path = r.getPath(ctx, createdFolder.ID, &resp.Diagnostics)
+	plan.PermissionIDs = r.getPermissionIDs(ctx, createdFolder.ID)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -150,6 +242,10 @@ func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read refreshes the Terraform state with the latest data.
 func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_folder", &resp.Diagnostics) {
+		return
+	}
+
 	var state FolderResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -157,8 +253,20 @@ func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the folder from Passbolt
-	folder, err := r.client.GetFolder(ctx, state.ID.ValueString(), nil)
+	folder, err := r.client.GetFolder(ctx, state.ID.ValueString(), &api.GetFolderOptions{ContainPermissions: true})
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		folder, err = r.client.GetFolder(ctx, state.ID.ValueString(), &api.GetFolderOptions{ContainPermissions: true})
+	}
 	if err != nil {
 		// Check if the folder doesn't exist (was deleted outside of Terraform)
 		if isResourceNotFoundError(err) {
@@ -170,7 +278,7 @@ func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 		resp.Diagnostics.AddError(
 			"Error reading folder",
-			"Could not read folder, unexpected error: "+err.Error(),
+			"Could not read folder, unexpected error: "+describeError(err),
 		)
 		return
 	}
@@ -188,6 +296,8 @@ func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, res
 	} else {
 		state.FolderParent = types.StringNull()
 	}
+	state.Path = r.getPath(ctx, folder.ID, &resp.Diagnostics)
+	state.PermissionIDs = groupPermissionIDs(ctx, r.data.Lookups, folder.Permissions)
 
 	// Set the updated state
 	diags = resp.State.Set(ctx, state)
@@ -197,24 +307,87 @@ func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 }
 
-// isResourceNotFoundError checks if the error indicates that the resource doesn't exist
-func isResourceNotFoundError(err error) bool {
-	// Check for common "not found" error patterns
-	errorStr := err.Error()
-	return contains(errorStr, "does not exist") ||
-		contains(errorStr, "not found") ||
-		contains(errorStr, "404") ||
-		contains(errorStr, "Resource does not exist")
+// rollbackFailedCreate deletes a folder whose creation failed after it was stored in Passbolt when rollback_failed_creates
+// is enabled. Otherwise the folder stays in the state.
+func (r *FolderResource) rollbackFailedCreate(ctx context.Context, folderID, name string, resp *resource.CreateResponse) {
+	if !r.data.RollbackFailedCreates {
+		resp.Diagnostics.AddWarning(
+			"Created folder kept",
+			fmt.Sprintf("Folder '%s' (%s) was created before the failure and is recorded in the state, it is replaced on the next apply. "+
+				"Enable rollback_failed_creates to delete such folders right away.", name, folderID),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultDeleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteFolder(ctx, folderID)
+	r.data.Lookups.InvalidateFolders()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rolling back created folder",
+			fmt.Sprintf("Folder '%s' (%s) was created before the failure and could not be deleted, unexpected error: %s", name, folderID, describeError(err)),
+		)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// getPath returns the full path of a folder, adding a warning if it cannot be resolved.
+func (r *FolderResource) getPath(ctx context.Context, folderID string, diags *diag.Diagnostics) types.String {
+	folderPath, err := getFolderPath(ctx, r.client, folderID)
+	if err != nil {
+		diags.AddWarning("Cannot resolve folder path", err.Error())
+		return types.StringNull()
+	}
+	return types.StringValue(folderPath)
 }
 
-// contains checks if a string contains a substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && s[:len(substr)] == substr ||
-		len(s) > len(substr) && contains(s[1:], substr)
+// getFolderPath resolves the full path of a folder by walking up its parent folders.
+func getFolderPath(ctx context.Context, client *api.Client, folderID string) (string, error) {
+	var names []string
+	visited := make(map[string]bool)
+	for folderID != "" {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		// Guard against cycles in inconsistent folder hierarchies
+		if visited[folderID] {
+			return "", fmt.Errorf("folder '%s' is its own ancestor", folderID)
+		}
+		visited[folderID] = true
+
+		folder, err := client.GetFolder(ctx, folderID, nil)
+		if err != nil {
+			return "", err
+		}
+		names = append([]string{folder.Name}, names...)
+		folderID = folder.FolderParentID
+	}
+	return strings.Join(names, "/"), nil
+}
+
+// folderParentNotFound reports a folder_parent naming no folder. The name is usually stale because the current parent
+// folder was renamed in Passbolt, its new name is then part of the error.
+func folderParentNotFound(name, currentParentID string, folders []api.Folder, diags *diag.Diagnostics) {
+	detail := fmt.Sprintf("Parent folder '%s' not found", name)
+	for _, folder := range folders {
+		if currentParentID != "" && folder.ID == currentParentID {
+			detail += fmt.Sprintf(". The current parent folder %s is named '%s' in Passbolt, update folder_parent if it was renamed", folder.ID, folder.Name)
+			break
+		}
+	}
+	diags.AddError("Validation Error", detail)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_folder", &resp.Diagnostics) {
+		return
+	}
+
 	var plan FolderResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -229,12 +402,21 @@ func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get current folder to check what needs to be updated
 	currentFolder, err := r.client.GetFolder(ctx, state.ID.ValueString(), nil)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading current folder",
-			"Could not read current folder, unexpected error: "+err.Error(),
+			"Could not read current folder, unexpected error: "+describeError(err),
 		)
 		return
 	}
@@ -254,20 +436,10 @@ func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// If we need to recreate, delete and create new folder
 	if needsRecreation {
-		// Delete the old folder
-		err = r.client.DeleteFolder(ctx, state.ID.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting old folder",
-				"Could not delete old folder, unexpected error: "+err.Error(),
-			)
-			return
-		}
-
-		// Get parent folder ID if specified
+		// Resolve the parent folder before deleting the old folder, a stale name must not move it to the root
 		var parentFolderID string
 		if !plan.FolderParent.IsNull() && !plan.FolderParent.IsUnknown() {
-			folders, err := r.client.GetFolders(ctx, nil)
+			folders, err := r.data.Lookups.Folders(ctx)
 			if err != nil {
 				resp.Diagnostics.AddError("Cannot get folders", err.Error())
 				return
@@ -279,6 +451,26 @@ func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest,
 					break
 				}
 			}
+
+			if parentFolderID == "" {
+				folderParentNotFound(plan.FolderParent.ValueString(), currentFolder.FolderParentID, folders, &resp.Diagnostics)
+				return
+			}
+		}
+
+		if isCancelled(ctx, &resp.Diagnostics) {
+			return
+		}
+
+		// Delete the old folder
+		err = r.client.DeleteFolder(ctx, state.ID.ValueString())
+		r.data.Lookups.InvalidateFolders()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting old folder",
+				"Could not delete old folder, unexpected error: "+describeError(err),
+			)
+			return
 		}
 
 		// Create the new folder
@@ -288,6 +480,7 @@ func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 
 		createdFolder, err := r.client.CreateFolder(ctx, folder)
+		r.data.Lookups.InvalidateFolders()
 		if err != nil {
 			resp.Diagnostics.AddError("Cannot recreate folder", err.Error())
 			return
@@ -296,11 +489,27 @@ func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest,
 		// Update the state ID
 		state.ID = types.StringValue(createdFolder.ID)
 		state.Personal = types.BoolValue(createdFolder.Personal)
+
+		// The new folder has none of the previous permissions
+		state.Shares = types.SetNull(plan.Shares.ElementType(ctx))
+	}
+
+	// Shares are propagated again when propagation is turned on, the content may not have them yet
+	propagate := plan.Propagate.ValueBool() && !needsRecreation
+	if !plan.Shares.Equal(state.Shares) || propagate && !state.Propagate.ValueBool() {
+		r.updateShares(ctx, state.ID.ValueString(), &state, plan, propagate, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Update state with the new values from the plan
 	state.Name = plan.Name
 	state.FolderParent = plan.FolderParent
+	state.Shares = plan.Shares
+	state.Propagate = plan.Propagate
+	state.Path = r.getPath(ctx, state.ID.ValueString(), &resp.Diagnostics)
+	state.PermissionIDs = r.getPermissionIDs(ctx, state.ID.ValueString())
 
 	// Set the updated state
 	diags = resp.State.Set(ctx, state)
@@ -310,8 +519,149 @@ func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 }
 
+// updateShares applies the planned shares to a folder, and to the password resources it contains when propagate is set.
+// Only the groups that were shared by Terraform before are unshared, the state is nil for new folders.
+func (r *FolderResource) updateShares(ctx context.Context, folderID string, state *FolderResourceModel, plan FolderResourceModel, propagate bool, diags *diag.Diagnostics) {
+	var previous, planned []PasswordShareModel
+	if state != nil && !state.Shares.IsNull() && !state.Shares.IsUnknown() {
+		diags.Append(state.Shares.ElementsAs(ctx, &previous, false)...)
+	}
+	if !plan.Shares.IsNull() && !plan.Shares.IsUnknown() {
+		diags.Append(plan.Shares.ElementsAs(ctx, &planned, false)...)
+	}
+	if diags.HasError() || len(previous) == 0 && len(planned) == 0 {
+		return
+	}
+
+	groupIDs, err := getGroupIDs(ctx, r.data.Lookups)
+	if err != nil {
+		diags.AddError("Cannot get groups", err.Error())
+		return
+	}
+
+	plannedOperations, err := resolveShares(planned, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return
+	}
+
+	// Groups that were deleted in the meantime cannot be unshared anymore
+	var existing []PasswordShareModel
+	for _, share := range previous {
+		if _, ok := groupIDs[share.Group.ValueString()]; ok {
+			existing = append(existing, share)
+		}
+	}
+	previousOperations, err := resolveShares(existing, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return
+	}
+
+	folder, err := r.client.GetFolder(ctx, folderID, &api.GetFolderOptions{ContainPermissions: true})
+	if err != nil {
+		diags.AddError("Cannot get folder permissions", err.Error())
+		return
+	}
+
+	changes := shareChanges(folder.Permissions, previousOperations, plannedOperations)
+	if len(changes) > 0 {
+		err = helper.ShareFolder(ctx, r.client, folderID, changes)
+		if err != nil {
+			diags.AddError("Cannot share folder", err.Error())
+			return
+		}
+	}
+
+	if !propagate {
+		return
+	}
+
+	folderIDs, err := folderDescendants(ctx, r.data.Lookups, folderID)
+	if err != nil {
+		diags.AddError("Cannot get folders", err.Error())
+		return
+	}
+
+	resources, err := r.client.GetResources(ctx, &api.GetResourcesOptions{FilterHasParent: folderIDs})
+	if err != nil {
+		diags.AddError("Cannot get folder resources", err.Error())
+		return
+	}
+
+	for _, resource := range resources {
+		if isCancelled(ctx, diags) {
+			return
+		}
+
+		permissions, err := r.client.GetResourcePermissions(ctx, resource.ID)
+		if err != nil {
+			diags.AddError("Cannot get resource permissions", err.Error())
+			return
+		}
+
+		changes := shareChanges(permissions, previousOperations, plannedOperations)
+		if len(changes) == 0 {
+			continue
+		}
+
+		err = shareResource(ctx, r.data, resource.ID, changes)
+		if err != nil {
+			diags.AddError("Cannot share resource", fmt.Sprintf("Could not propagate the shares of the folder to resource '%s' (%s): %s", resource.Name, resource.ID, err.Error()))
+			return
+		}
+	}
+}
+
+// getPermissionIDs returns the IDs of the group permissions of a folder, or null if they cannot be read.
+func (r *FolderResource) getPermissionIDs(ctx context.Context, folderID string) types.Map {
+	folder, err := r.client.GetFolder(ctx, folderID, &api.GetFolderOptions{ContainPermissions: true})
+	if err != nil {
+		return types.MapNull(types.StringType)
+	}
+	return groupPermissionIDs(ctx, r.data.Lookups, folder.Permissions)
+}
+
+// folderDescendants returns the IDs of a folder and all of its subfolders.
+func folderDescendants(ctx context.Context, lookups *Lookups, folderID string) ([]string, error) {
+	folders, err := lookups.Folders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]string)
+	for _, folder := range folders {
+		children[folder.FolderParentID] = append(children[folder.FolderParentID], folder.ID)
+	}
+
+	folderIDs := []string{folderID}
+	for i := 0; i < len(folderIDs); i++ {
+		folderIDs = append(folderIDs, children[folderIDs[i]]...)
+	}
+	return folderIDs, nil
+}
+
+// MoveState moves resources managed by other Passbolt providers into this provider.
+func (r *FolderResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				moveRawState(ctx, req, resp, movedFolderTypes, []movedAttribute{
+					{Target: "id", Sources: []string{"id"}},
+					{Target: "name", Sources: []string{"name"}},
+					{Target: "folder_parent", Sources: []string{"folder_parent", "parent"}},
+				})
+			},
+		},
+	}
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *FolderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_folder", &resp.Diagnostics) {
+		return
+	}
+
 	var state FolderResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -319,12 +669,27 @@ func (r *FolderResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Delete the folder
-	err := r.client.DeleteFolder(ctx, state.ID.ValueString())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the folder, the status code of the response tells whether it was already deleted
+	_, err := doCustomRequest(ctx, r.client, "DELETE", "/folders/"+state.ID.ValueString()+".json", nil, nil)
+	r.data.Lookups.InvalidateFolders()
 	if err != nil {
+		// Already deleted outside of Terraform
+		if isResourceNotFoundError(err) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error deleting folder",
-			"Could not delete folder, unexpected error: "+err.Error(),
+			"Could not delete folder, unexpected error: "+describeError(err),
 		)
 		return
 	}
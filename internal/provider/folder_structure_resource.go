@@ -0,0 +1,508 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &FolderStructureResource{}
+	_ resource.ResourceWithConfigure = &FolderStructureResource{}
+)
+
+// folderPathSeparator separates the folder names of a path in passbolt_folder_structure.
+const folderPathSeparator = "/"
+
+// Number of sibling folders created at the same time by passbolt_folder_structure.
+const (
+	defaultFolderParallelism = 8
+	maxFolderParallelism     = 32
+)
+
+// NewFolderStructureResource is a helper function to simplify the provider implementation.
+func NewFolderStructureResource() resource.Resource {
+	return &FolderStructureResource{}
+}
+
+// FolderStructureResource is the resource implementation.
+type FolderStructureResource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// FolderStructureResourceModel describes the resource data model.
+type FolderStructureResourceModel struct {
+	ID             types.String            `tfsdk:"id"`
+	FolderParentID types.String            `tfsdk:"folder_parent_id"`
+	Paths          []types.String          `tfsdk:"paths"`
+	Parallelism    types.Int64             `tfsdk:"parallelism"`
+	FolderIDs      map[string]types.String `tfsdk:"folder_ids"`
+	Timeouts       timeouts.Value          `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *FolderStructureResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.data = data
+
+	requirePlugin(data, pluginFolders, "passbolt_folder_structure", &resp.Diagnostics)
+}
+
+// Metadata returns the resource type name.
+func (r *FolderStructureResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder_structure"
+}
+
+// Schema defines the schema for the resource.
+func (r *FolderStructureResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the folder structure",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"folder_parent_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the folder to create the structure in, the structure is created at the root when not set",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"paths": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The folder paths to create, such as Team/Prod/DB, the intermediate folders are created as well",
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("The number of folders created at the same time, folders are only created once their parent exists "+
+					"(default %d, at most %d)", defaultFolderParallelism, maxFolderParallelism),
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxFolderParallelism),
+				},
+			},
+			"folder_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the created folders keyed by path, including the intermediate folders",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *FolderStructureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_folder_structure", &resp.Diagnostics) {
+		return
+	}
+
+	var plan FolderStructureResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	paths, err := expandFolderPaths(plan.Paths)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	// Record the created folders even on failure, so they are tracked and can be cleaned up
+	plan.FolderIDs = make(map[string]types.String, len(paths))
+	err = r.createFolders(ctx, plan, paths, plan.FolderIDs)
+	plan.ID = types.StringValue(folderStructureID(plan.FolderIDs))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating folder structure",
+			"Could not create folder structure, unexpected error: "+describeError(err),
+		)
+		if len(plan.FolderIDs) == 0 {
+			return
+		}
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *FolderStructureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_folder_structure", &resp.Diagnostics) {
+		return
+	}
+
+	var state FolderStructureResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	folders, err := r.data.Lookups.Folders(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading folders",
+			"Could not read folders, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	existing := make(map[string]bool, len(folders))
+	for _, folder := range folders {
+		existing[folder.ID] = true
+	}
+
+	// Forget the folders deleted outside of Terraform so the next apply recreates them
+	for folderPath, folderID := range state.FolderIDs {
+		if !existing[folderID.ValueString()] {
+			delete(state.FolderIDs, folderPath)
+		}
+	}
+
+	// A path is only kept when its folder and all its parents still exist
+	paths := make([]types.String, 0, len(state.Paths))
+	for _, folderPath := range state.Paths {
+		names := strings.Split(folderPath.ValueString(), folderPathSeparator)
+		complete := true
+		for i := range names {
+			if _, ok := state.FolderIDs[strings.Join(names[:i+1], folderPathSeparator)]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			paths = append(paths, folderPath)
+		}
+	}
+	state.Paths = paths
+
+	if len(state.FolderIDs) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *FolderStructureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_folder_structure", &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state FolderStructureResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	paths, err := expandFolderPaths(plan.Paths)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	wanted := make(map[string]bool, len(paths))
+	for _, folderPath := range paths {
+		wanted[folderPath] = true
+	}
+
+	// Keep the IDs of the folders that are still wanted, delete the others deepest first
+	folderIDs := make(map[string]types.String, len(paths))
+	removed := make([]string, 0)
+	for folderPath, folderID := range state.FolderIDs {
+		if wanted[folderPath] {
+			folderIDs[folderPath] = folderID
+		} else {
+			removed = append(removed, folderPath)
+		}
+	}
+	sortFolderPaths(removed)
+
+	plan.ID = state.ID
+	plan.FolderIDs = folderIDs
+	for i := len(removed) - 1; i >= 0; i-- {
+		err := r.client.DeleteFolder(ctx, state.FolderIDs[removed[i]].ValueString())
+		r.data.Lookups.InvalidateFolders()
+		if err != nil && !isResourceNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Error deleting folder",
+				fmt.Sprintf("Could not delete folder '%s', unexpected error: %s", removed[i], describeError(err)),
+			)
+			// Keep tracking the folders that were not deleted yet
+			for _, folderPath := range removed[:i+1] {
+				plan.FolderIDs[folderPath] = state.FolderIDs[folderPath]
+			}
+			plan.Paths = state.Paths
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			return
+		}
+	}
+
+	err = r.createFolders(ctx, plan, paths, plan.FolderIDs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating folder structure",
+			"Could not create folder, unexpected error: "+describeError(err),
+		)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *FolderStructureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_folder_structure", &resp.Diagnostics) {
+		return
+	}
+
+	var state FolderStructureResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	paths := make([]string, 0, len(state.FolderIDs))
+	for folderPath := range state.FolderIDs {
+		paths = append(paths, folderPath)
+	}
+	sortFolderPaths(paths)
+
+	// Delete the deepest folders first so no folder is deleted with children in it
+	for i := len(paths) - 1; i >= 0; i-- {
+		err := r.client.DeleteFolder(ctx, state.FolderIDs[paths[i]].ValueString())
+		r.data.Lookups.InvalidateFolders()
+		if err != nil && !isResourceNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Error deleting folder",
+				fmt.Sprintf("Could not delete folder '%s', unexpected error: %s", paths[i], describeError(err)),
+			)
+			return
+		}
+	}
+}
+
+// createFolders creates the folders of the given paths missing from folderIDs. The folders of a depth are created
+// concurrently once all the folders of the previous depth exist, the paths being sorted parents before children.
+func (r *FolderStructureResource) createFolders(ctx context.Context, plan FolderStructureResourceModel, paths []string, folderIDs map[string]types.String) error {
+	parallelism := defaultFolderParallelism
+	if !plan.Parallelism.IsNull() {
+		parallelism = int(plan.Parallelism.ValueInt64())
+	}
+
+	for start := 0; start < len(paths); {
+		depth := strings.Count(paths[start], folderPathSeparator)
+		end := start
+		for end < len(paths) && strings.Count(paths[end], folderPathSeparator) == depth {
+			end++
+		}
+
+		err := r.createSiblingFolders(ctx, plan.FolderParentID.ValueString(), paths[start:end], folderIDs, parallelism)
+		if err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// createSiblingFolders creates the folders of paths of the same depth missing from folderIDs, at most parallelism at a time.
+// The folders created before a failure are recorded in folderIDs as well.
+func (r *FolderStructureResource) createSiblingFolders(ctx context.Context, rootID string, paths []string, folderIDs map[string]types.String, parallelism int) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs []string
+	slots := make(chan struct{}, parallelism)
+
+	for _, folderPath := range paths {
+		// folderIDs is written by the goroutines of the previous paths
+		mu.Lock()
+		_, exists := folderIDs[folderPath]
+		parentID := rootID
+		name := folderPath
+		if i := strings.LastIndex(folderPath, folderPathSeparator); i >= 0 {
+			parentID = folderIDs[folderPath[:i]].ValueString()
+			name = folderPath[i+1:]
+		}
+		mu.Unlock()
+		if exists {
+			continue
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(folderPath, parentID, name string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			folder, err := r.client.CreateFolder(ctx, api.Folder{
+				FolderParentID: parentID,
+				Name:           name,
+			})
+			r.data.Lookups.InvalidateFolders()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("folder '%s': %s", folderPath, err.Error()))
+				return
+			}
+			folderIDs[folderPath] = types.StringValue(folder.ID)
+		}(folderPath, parentID, name)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// expandFolderPaths returns the given paths and all their intermediate paths, parents before children.
+func expandFolderPaths(paths []types.String) ([]string, error) {
+	expanded := make(map[string]bool)
+	for _, folderPath := range paths {
+		names := strings.Split(folderPath.ValueString(), folderPathSeparator)
+		for i, name := range names {
+			if strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("folder path '%s' contains an empty folder name", folderPath.ValueString())
+			}
+			expanded[strings.Join(names[:i+1], folderPathSeparator)] = true
+		}
+	}
+
+	result := make([]string, 0, len(expanded))
+	for folderPath := range expanded {
+		result = append(result, folderPath)
+	}
+	sortFolderPaths(result)
+	return result, nil
+}
+
+// sortFolderPaths sorts folder paths by depth then name, so parents come before their children.
+func sortFolderPaths(paths []string) {
+	sort.Slice(paths, func(i, j int) bool {
+		di := strings.Count(paths[i], folderPathSeparator)
+		dj := strings.Count(paths[j], folderPathSeparator)
+		if di != dj {
+			return di < dj
+		}
+		return paths[i] < paths[j]
+	})
+}
+
+// folderStructureID returns a hash of the IDs of the folders created with the structure.
+func folderStructureID(folderIDs map[string]types.String) string {
+	ids := make([]string, 0, len(folderIDs))
+	for _, folderID := range folderIDs {
+		ids = append(ids, folderID.ValueString())
+	}
+	sort.Strings(ids)
+
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
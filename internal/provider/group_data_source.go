@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &GroupDataSource{}
+	_ datasource.DataSourceWithConfigure = &GroupDataSource{}
+)
+
+// NewGroupDataSource is a helper function to simplify the provider implementation.
+func NewGroupDataSource() datasource.DataSource {
+	return &GroupDataSource{}
+}
+
+// GroupDataSource is the data source implementation.
+type GroupDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// GroupDataSourceModel describes the data source data model.
+type GroupDataSourceModel struct {
+	ID       types.String       `tfsdk:"id"`
+	Name     types.String       `tfsdk:"name"`
+	Managers []types.String     `tfsdk:"managers"`
+	Members  []GroupMemberModel `tfsdk:"members"`
+}
+
+// GroupMemberModel describes a single group membership.
+type GroupMemberModel struct {
+	UserID    types.String `tfsdk:"user_id"`
+	Username  types.String `tfsdk:"username"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	IsManager types.Bool   `tfsdk:"is_manager"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *GroupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique identifier of the group, either id or name must be set",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the group, either id or name must be set",
+			},
+			"managers": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the users managing the group",
+			},
+			"members": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of all group memberships, including the group managers",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the user",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username (email) of the user",
+						},
+						"first_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The first name of the user",
+						},
+						"last_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The last name of the user",
+						},
+						"is_manager": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the user is a group manager",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_group", &resp.Diagnostics) {
+		return
+	}
+
+	var state GroupDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.IsNull() && state.Name.IsNull() {
+		resp.Diagnostics.AddError("Validation Error", "Either id or name must be set")
+		return
+	}
+
+	// Get all groups with their memberships from Passbolt
+	groups, err := d.client.GetGroups(ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers:            true,
+		ContainGroupsUsersUser:        true,
+		ContainGroupsUsersUserProfile: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading groups",
+			"Could not read groups, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var group *api.Group
+	for i := range groups {
+		if !state.ID.IsNull() && groups[i].ID != state.ID.ValueString() {
+			continue
+		}
+		if !state.Name.IsNull() && groups[i].Name != state.Name.ValueString() {
+			continue
+		}
+		group = &groups[i]
+		break
+	}
+
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Group not found",
+			fmt.Sprintf("No group matches id '%s' and name '%s'", state.ID.ValueString(), state.Name.ValueString()),
+		)
+		return
+	}
+
+	// Sort memberships so the output is stable between reads
+	memberships := group.GroupUsers
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].User.Username < memberships[j].User.Username
+	})
+
+	managers := make([]types.String, 0)
+	members := make([]GroupMemberModel, 0, len(memberships))
+	for _, membership := range memberships {
+		member := GroupMemberModel{
+			UserID:    types.StringValue(membership.UserID),
+			Username:  types.StringValue(membership.User.Username),
+			FirstName: types.StringNull(),
+			LastName:  types.StringNull(),
+			IsManager: types.BoolValue(membership.IsAdmin),
+		}
+
+		if membership.User.Profile != nil {
+			member.FirstName = types.StringValue(membership.User.Profile.FirstName)
+			member.LastName = types.StringValue(membership.User.Profile.LastName)
+		}
+
+		if membership.IsAdmin {
+			managers = append(managers, types.StringValue(membership.UserID))
+		}
+
+		members = append(members, member)
+	}
+
+	state.ID = types.StringValue(group.ID)
+	state.Name = types.StringValue(group.Name)
+	state.Managers = managers
+	state.Members = members
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
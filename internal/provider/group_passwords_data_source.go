@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &GroupPasswordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &GroupPasswordsDataSource{}
+)
+
+// NewGroupPasswordsDataSource is a helper function to simplify the provider implementation.
+func NewGroupPasswordsDataSource() datasource.DataSource {
+	return &GroupPasswordsDataSource{}
+}
+
+// GroupPasswordsDataSource is the data source implementation.
+type GroupPasswordsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// GroupPasswordsDataSourceModel describes the data source data model.
+type GroupPasswordsDataSourceModel struct {
+	GroupID   types.String         `tfsdk:"group_id"`
+	GroupName types.String         `tfsdk:"group_name"`
+	Passwords []GroupPasswordModel `tfsdk:"passwords"`
+}
+
+// GroupPasswordModel describes a single password resource accessible to a group.
+type GroupPasswordModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Username       types.String `tfsdk:"username"`
+	URI            types.String `tfsdk:"uri"`
+	FolderParentID types.String `tfsdk:"folder_parent_id"`
+	Inherited      types.Bool   `tfsdk:"inherited"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupPasswordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *GroupPasswordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_passwords"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupPasswordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique identifier of the group, either group_id or group_name must be set",
+			},
+			"group_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the group, either group_id or group_name must be set",
+			},
+			"passwords": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of password resources the group can access, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the password resource",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the password resource",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username for the password resource",
+						},
+						"uri": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URI for the password resource",
+						},
+						"folder_parent_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the folder containing the password resource",
+						},
+						"inherited": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the group only has access through a folder shared with it",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupPasswordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_group_passwords", &resp.Diagnostics) {
+		return
+	}
+
+	var state GroupPasswordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.GroupID.IsNull() && state.GroupName.IsNull() {
+		resp.Diagnostics.AddError("Validation Error", "Either group_id or group_name must be set")
+		return
+	}
+
+	groups, err := d.data.Lookups.Groups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading groups",
+			"Could not read groups, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var group *api.Group
+	for i := range groups {
+		if !state.GroupID.IsNull() && groups[i].ID != state.GroupID.ValueString() {
+			continue
+		}
+		if !state.GroupName.IsNull() && groups[i].Name != state.GroupName.ValueString() {
+			continue
+		}
+		group = &groups[i]
+		break
+	}
+
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Group not found",
+			fmt.Sprintf("No group matches id '%s' and name '%s'", state.GroupID.ValueString(), state.GroupName.ValueString()),
+		)
+		return
+	}
+
+	// Resources shared with the group directly
+	shared, err := d.client.GetResources(ctx, &api.GetResourcesOptions{
+		FilterIsSharedWithGroup: group.ID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading passwords",
+			"Could not read passwords, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	passwords := make(map[string]GroupPasswordModel, len(shared))
+	for _, resource := range shared {
+		passwords[resource.ID] = groupPasswordModel(resource, false)
+	}
+
+	// Resources inside folders shared with the group, including nested folders
+	if d.data.Capabilities.HasPlugin(pluginFolders) {
+		folderIDs, err := getGroupFolderIDs(ctx, d.client, group.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading folders",
+				"Could not read folders, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		if len(folderIDs) > 0 {
+			resources, err := d.client.GetResources(ctx, &api.GetResourcesOptions{
+				FilterHasParent: folderIDs,
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading passwords",
+					"Could not read passwords, unexpected error: "+describeError(err),
+				)
+				return
+			}
+
+			for _, resource := range resources {
+				if _, ok := passwords[resource.ID]; !ok {
+					passwords[resource.ID] = groupPasswordModel(resource, true)
+				}
+			}
+		}
+	}
+
+	state.Passwords = make([]GroupPasswordModel, 0, len(passwords))
+	for _, password := range passwords {
+		state.Passwords = append(state.Passwords, password)
+	}
+	sort.Slice(state.Passwords, func(i, j int) bool {
+		if state.Passwords[i].Name.ValueString() != state.Passwords[j].Name.ValueString() {
+			return state.Passwords[i].Name.ValueString() < state.Passwords[j].Name.ValueString()
+		}
+		return state.Passwords[i].ID.ValueString() < state.Passwords[j].ID.ValueString()
+	})
+
+	state.GroupID = types.StringValue(group.ID)
+	state.GroupName = types.StringValue(group.Name)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// groupPasswordModel converts a resource into its data source representation.
+func groupPasswordModel(resource api.Resource, inherited bool) GroupPasswordModel {
+	folderParentID := types.StringNull()
+	if resource.FolderParentID != "" {
+		folderParentID = types.StringValue(resource.FolderParentID)
+	}
+
+	return GroupPasswordModel{
+		ID:             types.StringValue(resource.ID),
+		Name:           types.StringValue(resource.Name),
+		Username:       types.StringValue(resource.Username),
+		URI:            types.StringValue(resource.URI),
+		FolderParentID: folderParentID,
+		Inherited:      types.BoolValue(inherited),
+	}
+}
+
+// getGroupFolderIDs returns the IDs of the folders shared with a group and of all folders nested below them.
+func getGroupFolderIDs(ctx context.Context, client *api.Client, groupID string) ([]string, error) {
+	folders, err := client.GetFolders(ctx, &api.GetFoldersOptions{
+		ContainPermissions: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]string)
+	queue := make([]string, 0)
+	for _, folder := range folders {
+		children[folder.FolderParentID] = append(children[folder.FolderParentID], folder.ID)
+		for _, permission := range folder.Permissions {
+			if permission.ARO == "Group" && permission.AROForeignKey == groupID {
+				queue = append(queue, folder.ID)
+				break
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	folderIDs := make([]string, 0)
+	for len(queue) > 0 {
+		folderID := queue[0]
+		queue = queue[1:]
+		if seen[folderID] {
+			continue
+		}
+		seen[folderID] = true
+		folderIDs = append(folderIDs, folderID)
+		queue = append(queue, children[folderID]...)
+	}
+	return folderIDs, nil
+}
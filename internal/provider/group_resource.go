@@ -0,0 +1,563 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &GroupResource{}
+	_ resource.ResourceWithConfigure      = &GroupResource{}
+	_ resource.ResourceWithValidateConfig = &GroupResource{}
+	_ resource.ResourceWithModifyPlan     = &GroupResource{}
+)
+
+// NewGroupResource is a helper function to simplify the provider implementation.
+func NewGroupResource() resource.Resource {
+	return &GroupResource{}
+}
+
+// GroupResource is the resource implementation.
+type GroupResource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// GroupResourceModel describes the resource data model.
+type GroupResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Name            types.String   `tfsdk:"name"`
+	Managers        types.Set      `tfsdk:"managers"`
+	Members         types.Set      `tfsdk:"members"`
+	AdditiveMembers types.Bool     `tfsdk:"additive_members"`
+	CurrentMembers  types.Set      `tfsdk:"current_members"`
+	TransferTo      types.String   `tfsdk:"transfer_ownership_to"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *GroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.data = data
+}
+
+// Metadata returns the resource type name.
+func (r *GroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *GroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the group",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the group",
+			},
+			"managers": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the users managing the group, at least one is required",
+			},
+			"members": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the users that are regular members of the group. " +
+					"When set, the list is authoritative and members not listed here are removed from the group. " +
+					"Leave unset when memberships are managed outside of this resource.",
+			},
+			"additive_members": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Only add the users listed in members and never remove members added outside of Terraform, such as by a directory sync. " +
+					"Users removed from members are still removed from the group.",
+			},
+			"current_members": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of all users that are regular members of the group in Passbolt, including the ones added outside of Terraform " +
+					"by administrators or a directory sync, whatever members and additive_members are set to",
+			},
+			"transfer_ownership_to": schema.StringAttribute{
+				Optional: true,
+				Description: "The ID of the user or group receiving the ownership of the resources and folders the group is the only owner of when it is deleted. " +
+					"The user or group must already have access to them",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig validates the memberships of the group.
+func (r *GroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config GroupResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Managers.IsUnknown() || config.Members.IsUnknown() {
+		return
+	}
+
+	if !config.Managers.IsNull() && len(config.Managers.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managers"),
+			"Validation Error",
+			"A group needs at least one manager",
+		)
+	}
+
+	managers := setToStrings(ctx, config.Managers, &resp.Diagnostics)
+	members := setToStrings(ctx, config.Members, &resp.Diagnostics)
+	for _, member := range members {
+		for _, manager := range managers {
+			if member == manager {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("members"),
+					"Conflicting Group Membership",
+					fmt.Sprintf("User '%s' is listed in both managers and members, a user can only hold one membership per group", member),
+				)
+			}
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_group", &resp.Diagnostics) {
+		return
+	}
+
+	var plan GroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Validate input
+	if plan.Name.ValueString() == "" {
+		resp.Diagnostics.AddError("Validation Error", "Name cannot be empty")
+		return
+	}
+
+	managers := setToStrings(ctx, plan.Managers, &resp.Diagnostics)
+	members := setToStrings(ctx, plan.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	operations := make([]helper.GroupMembershipOperation, 0, len(managers)+len(members))
+	for _, userID := range managers {
+		operations = append(operations, helper.GroupMembershipOperation{UserID: userID, IsGroupManager: true})
+	}
+	for _, userID := range members {
+		operations = append(operations, helper.GroupMembershipOperation{UserID: userID})
+	}
+
+	// Create the group
+	groupID, err := helper.CreateGroup(ctx, r.client, plan.Name.ValueString(), operations)
+	r.data.Lookups.InvalidateGroups()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating group",
+			"Could not create group, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Set the computed values
+	plan.ID = types.StringValue(groupID)
+	plan.CurrentMembers = r.getCurrentMembers(ctx, groupID, &resp.Diagnostics)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_group", &resp.Diagnostics) {
+		return
+	}
+
+	var state GroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get the group from Passbolt
+	group, err := r.getGroup(ctx, state.ID.ValueString())
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		group, err = r.getGroup(ctx, state.ID.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading group",
+			"Could not read group, unexpected error: "+describeError(err),
+		)
+		return
+	}
+	if group == nil {
+		// Group no longer exists, remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	managers, members := groupMemberships(group)
+
+	// Update the state with the current values from Passbolt
+	state.Name = types.StringValue(group.Name)
+	state.Managers = stringsToSet(managers, &resp.Diagnostics)
+	state.CurrentMembers = stringsToSet(members, &resp.Diagnostics)
+
+	// Only track regular members when they are managed inline
+	if !state.Members.IsNull() && state.AdditiveMembers.ValueBool() {
+		// Members added outside of Terraform are ignored, only the declared ones are tracked
+		declared := setToStrings(ctx, state.Members, &resp.Diagnostics)
+
+		var present []string
+		for _, member := range declared {
+			if containsString(members, member) {
+				present = append(present, member)
+			}
+		}
+		state.Members = stringsToSet(present, &resp.Diagnostics)
+	} else if !state.Members.IsNull() {
+		declared := setToStrings(ctx, state.Members, &resp.Diagnostics)
+
+		var undeclared []string
+		for _, member := range members {
+			if !containsString(declared, member) {
+				undeclared = append(undeclared, member)
+			}
+		}
+
+		if len(undeclared) > 0 {
+			sort.Strings(undeclared)
+			resp.Diagnostics.AddWarning(
+				"Group members managed outside of passbolt_group",
+				fmt.Sprintf("Group '%s' has members that are not declared in its members attribute: %s. "+
+					"The members attribute is authoritative, so these users will be removed on the next apply. "+
+					"If they are managed elsewhere, such as another configuration or a directory sync, remove the members attribute from this group.",
+					group.Name, strings.Join(undeclared, ", ")),
+			)
+		}
+
+		state.Members = stringsToSet(members, &resp.Diagnostics)
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_group", &resp.Diagnostics) {
+		return
+	}
+
+	var plan GroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state GroupResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Get current group to check what needs to be updated
+	currentGroup, err := r.getGroup(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading current group",
+			"Could not read current group, unexpected error: "+describeError(err),
+		)
+		return
+	}
+	if currentGroup == nil {
+		resp.Diagnostics.AddError(
+			"Error reading current group",
+			fmt.Sprintf("Group '%s' no longer exists", state.ID.ValueString()),
+		)
+		return
+	}
+
+	managers := setToStrings(ctx, plan.Managers, &resp.Diagnostics)
+	members := setToStrings(ctx, plan.Members, &resp.Diagnostics)
+	previousMembers := setToStrings(ctx, state.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	additive := plan.AdditiveMembers.ValueBool()
+
+	// Desired role per user, true for group managers
+	desired := make(map[string]bool, len(managers)+len(members))
+	for _, userID := range members {
+		desired[userID] = false
+	}
+	for _, userID := range managers {
+		desired[userID] = true
+	}
+
+	var operations []helper.GroupMembershipOperation
+	current := make(map[string]bool, len(currentGroup.GroupUsers))
+	for _, membership := range currentGroup.GroupUsers {
+		current[membership.UserID] = true
+
+		isManager, ok := desired[membership.UserID]
+		switch {
+		case ok && isManager != membership.IsAdmin:
+			operations = append(operations, helper.GroupMembershipOperation{UserID: membership.UserID, IsGroupManager: isManager})
+		case !ok && (membership.IsAdmin || !plan.Members.IsNull() && (!additive || containsString(previousMembers, membership.UserID))):
+			// Managers are always authoritative, regular members only when declared inline,
+			// and in additive mode only the members that Terraform added before
+			operations = append(operations, helper.GroupMembershipOperation{UserID: membership.UserID, Delete: true})
+		}
+	}
+	for userID, isManager := range desired {
+		if !current[userID] {
+			operations = append(operations, helper.GroupMembershipOperation{UserID: userID, IsGroupManager: isManager})
+		}
+	}
+
+	// Update the group
+	if len(operations) > 0 || plan.Name.ValueString() != currentGroup.Name {
+		err = helper.UpdateGroup(ctx, r.client, state.ID.ValueString(), plan.Name.ValueString(), operations)
+		r.data.Lookups.InvalidateGroups()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating group",
+				"Could not update group, unexpected error: "+describeError(err),
+			)
+			return
+		}
+	}
+
+	// Update state with the new values from the plan
+	plan.ID = state.ID
+	plan.CurrentMembers = r.getCurrentMembers(ctx, state.ID.ValueString(), &resp.Diagnostics)
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan fails at plan time when groups would be changed without an administrator account.
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if plansChange(req) {
+		requireAdmin(ctx, r.data, "passbolt_group", &resp.Diagnostics)
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_group", &resp.Diagnostics) {
+		return
+	}
+
+	var state GroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the group, Passbolt refuses to delete the only owner of resources unless their ownership is transferred
+	var err error
+	if state.TransferTo.IsNull() {
+		err = r.client.DeleteGroup(ctx, state.ID.ValueString())
+	} else {
+		err = deleteWithTransfer(ctx, r.client, "/groups/"+state.ID.ValueString(), state.TransferTo.ValueString())
+	}
+	r.data.Lookups.InvalidateGroups()
+	if err != nil {
+		detail := "Could not delete group, unexpected error: " + describeError(err)
+		if state.TransferTo.IsNull() {
+			detail += "\n\nIf the group is the only owner of some resources, set transfer_ownership_to and apply before destroying it."
+		}
+		resp.Diagnostics.AddError("Error deleting group", detail)
+		return
+	}
+}
+
+// getGroup returns the group with its memberships, or nil if it does not exist.
+func (r *GroupResource) getGroup(ctx context.Context, groupID string) (*api.Group, error) {
+	// The single group endpoint does not return memberships, so the index is used instead
+	groups, err := r.client.GetGroups(ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.ID == groupID {
+			return &group, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getCurrentMembers returns the regular members of a group as they are in Passbolt.
+func (r *GroupResource) getCurrentMembers(ctx context.Context, groupID string, diags *diag.Diagnostics) types.Set {
+	group, err := r.getGroup(ctx, groupID)
+	if err != nil || group == nil {
+		// The memberships were applied, the list is filled again by the next refresh
+		if err == nil {
+			err = fmt.Errorf("group not found")
+		}
+		diags.AddWarning(
+			"Cannot read group members",
+			fmt.Sprintf("Could not read the members of group %s after applying the changes, current_members is read again on the next refresh: %s", groupID, err.Error()),
+		)
+		return types.SetNull(types.StringType)
+	}
+
+	_, members := groupMemberships(group)
+	return stringsToSet(members, diags)
+}
+
+// groupMemberships returns the IDs of the managers and of the regular members of a group, sorted.
+func groupMemberships(group *api.Group) ([]string, []string) {
+	managers := []string{}
+	members := []string{}
+	for _, membership := range group.GroupUsers {
+		if membership.IsAdmin {
+			managers = append(managers, membership.UserID)
+		} else {
+			members = append(members, membership.UserID)
+		}
+	}
+	sort.Strings(managers)
+	sort.Strings(members)
+	return managers, members
+}
+
+// setToStrings converts a set of strings to a slice.
+func setToStrings(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var values []string
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// stringsToSet converts a slice of strings to a set.
+func stringsToSet(values []string, diags *diag.Diagnostics) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+
+	set, d := types.SetValue(types.StringType, elements)
+	diags.Append(d...)
+	return set
+}
+
+// containsString checks if a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &GroupUsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &GroupUsersDataSource{}
+)
+
+// NewGroupUsersDataSource is a helper function to simplify the provider implementation.
+func NewGroupUsersDataSource() datasource.DataSource {
+	return &GroupUsersDataSource{}
+}
+
+// GroupUsersDataSource is the data source implementation.
+type GroupUsersDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// GroupUsersDataSourceModel describes the data source data model.
+type GroupUsersDataSourceModel struct {
+	Names    []types.String            `tfsdk:"names"`
+	Members  map[string][]types.String `tfsdk:"members"`
+	Managers map[string][]types.String `tfsdk:"managers"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *GroupUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The names of the groups to return, all groups visible to the authenticated user are returned when unset",
+			},
+			"members": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "Map of group name to the usernames (emails) of its members, managers included, sorted",
+			},
+			"managers": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "Map of group name to the usernames (emails) of its managers, sorted",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_group_users", &resp.Diagnostics) {
+		return
+	}
+
+	var state GroupUsersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All groups and their members come in a single request
+	groups, err := d.client.GetGroups(ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers:     true,
+		ContainGroupsUsersUser: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading groups",
+			"Could not read groups, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var wanted map[string]bool
+	if state.Names != nil {
+		wanted = make(map[string]bool, len(state.Names))
+		for _, name := range state.Names {
+			wanted[name.ValueString()] = true
+		}
+	}
+
+	state.Members = make(map[string][]types.String)
+	state.Managers = make(map[string][]types.String)
+	for _, group := range groups {
+		if wanted != nil && !wanted[group.Name] {
+			continue
+		}
+
+		var members, managers []string
+		for _, membership := range group.GroupUsers {
+			members = append(members, membership.User.Username)
+			if membership.IsAdmin {
+				managers = append(managers, membership.User.Username)
+			}
+		}
+		state.Members[group.Name] = sortedStringValues(members)
+		state.Managers[group.Name] = sortedStringValues(managers)
+	}
+
+	// A missing group is more likely a typo than an empty group
+	var missing []string
+	for name := range wanted {
+		if _, ok := state.Members[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		resp.Diagnostics.AddError(
+			"Group not found",
+			fmt.Sprintf("No group named '%s'", strings.Join(missing, "', '")),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// sortedStringValues sorts strings and converts them to Terraform values, never returning nil.
+func sortedStringValues(values []string) []types.String {
+	sort.Strings(values)
+
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
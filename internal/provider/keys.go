@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+)
+
+// errKeyCleared is returned when a key is used after the provider stopped.
+var errKeyCleared = errors.New("private key cleared")
+
+// unlockedKeys are the keys of every configured provider, they are cleared when the provider stops.
+var unlockedKeys struct {
+	sync.Mutex
+	keys []*UnlockedKey
+}
+
+// UnlockedKey is the private key of the authenticated user, unlocked on first use and kept unlocked for the lifetime
+// of the provider, so that decrypting many secrets unlocks the key once instead of once per secret as go-passbolt does.
+type UnlockedKey struct {
+	armored    string
+	passphrase []byte
+
+	once    sync.Once
+	mu      sync.RWMutex
+	keyRing *crypto.KeyRing
+	cleared bool
+	err     error
+}
+
+// NewUnlockedKey returns the given armored private key, to be unlocked with the given passphrase.
+// The key is registered to be cleared by ClearUnlockedKeys.
+func NewUnlockedKey(armored, passphrase string) *UnlockedKey {
+	key := &UnlockedKey{
+		armored:    armored,
+		passphrase: []byte(passphrase),
+	}
+
+	unlockedKeys.Lock()
+	defer unlockedKeys.Unlock()
+	unlockedKeys.keys = append(unlockedKeys.keys, key)
+
+	return key
+}
+
+// ClearUnlockedKeys wipes the unlocked keys and their passphrases from memory, once the provider stopped serving.
+func ClearUnlockedKeys() {
+	unlockedKeys.Lock()
+	defer unlockedKeys.Unlock()
+
+	for _, key := range unlockedKeys.keys {
+		key.clear()
+	}
+	unlockedKeys.keys = nil
+}
+
+// unlock unlocks the key on first use.
+func (k *UnlockedKey) unlock() error {
+	k.once.Do(func() {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+
+		if k.cleared {
+			k.err = errKeyCleared
+			return
+		}
+
+		key, err := crypto.NewKeyFromArmored(k.armored)
+		if err != nil {
+			k.err = fmt.Errorf("reading private key: %w", err)
+			return
+		}
+
+		unlocked, err := key.Unlock(k.passphrase)
+		if err != nil {
+			k.err = fmt.Errorf("unlocking private key: %w", err)
+			return
+		}
+
+		k.keyRing, k.err = crypto.NewKeyRing(unlocked)
+	})
+	return k.err
+}
+
+// use calls fn with the unlocked key, which must not be kept past the call.
+func (k *UnlockedKey) use(fn func(keyRing *crypto.KeyRing) error) error {
+	err := k.unlock()
+	if err != nil {
+		return err
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.cleared {
+		return errKeyCleared
+	}
+	return fn(k.keyRing)
+}
+
+// clear wipes the unlocked key and the passphrase, the key cannot be used afterwards.
+func (k *UnlockedKey) clear() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.keyRing != nil {
+		k.keyRing.ClearPrivateParams()
+		k.keyRing = nil
+	}
+	for i := range k.passphrase {
+		k.passphrase[i] = 0
+	}
+	k.cleared = true
+}
+
+// Decrypt decrypts an armored message encrypted for the authenticated user, such as a secret.
+func (k *UnlockedKey) Decrypt(armored string) (string, error) {
+	message, err := crypto.NewPGPMessageFromArmored(armored)
+	if err != nil {
+		return "", fmt.Errorf("reading message: %w", err)
+	}
+
+	// Like go-passbolt, the signature is not verified as the key of the user who encrypted the message is not known
+	var plain *crypto.PlainMessage
+	err = k.use(func(keyRing *crypto.KeyRing) (err error) {
+		plain, err = keyRing.Decrypt(message, nil, 0)
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("decrypting message: %w", err)
+	}
+	return plain.GetString(), nil
+}
+
+// EncryptFor encrypts a message for the owner of an armored public key and signs it, such as a secret being shared.
+func (k *UnlockedKey) EncryptFor(publicKey, message string) (string, error) {
+	key, err := crypto.NewKeyFromArmored(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("reading public key: %w", err)
+	}
+	publicKeyRing, err := crypto.NewKeyRing(key)
+	if err != nil {
+		return "", fmt.Errorf("reading public key: %w", err)
+	}
+
+	var encrypted *crypto.PGPMessage
+	err = k.use(func(keyRing *crypto.KeyRing) (err error) {
+		encrypted, err = publicKeyRing.Encrypt(crypto.NewPlainMessageFromString(message), keyRing)
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("encrypting message: %w", err)
+	}
+	return encrypted.GetArmored()
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Lookups memoizes the folder and group listings used to resolve names to IDs, the public keys used to share secrets
+// and the authenticated user for the lifetime of the provider, so that an apply touching many resources fetches them
+// once instead of once per resource.
+type Lookups struct {
+	client *api.Client
+
+	mu         sync.Mutex
+	folders    []api.Folder
+	groups     []api.Group
+	publicKeys map[string]string
+	me         *api.User
+}
+
+// NewLookups returns lookups backed by the given client.
+func NewLookups(client *api.Client) *Lookups {
+	return &Lookups{client: client}
+}
+
+// Folders returns all folders visible to the authenticated user, fetching them on first use.
+func (l *Lookups) Folders(ctx context.Context) ([]api.Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.folders == nil {
+		folders, err := l.client.GetFolders(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		l.folders = folders
+	}
+	return append([]api.Folder(nil), l.folders...), nil
+}
+
+// Groups returns all groups, fetching them on first use.
+func (l *Lookups) Groups(ctx context.Context) ([]api.Group, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.groups == nil {
+		groups, err := l.client.GetGroups(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		l.groups = groups
+	}
+	return append([]api.Group(nil), l.groups...), nil
+}
+
+// Me returns the authenticated user with its role, fetching it on first use.
+func (l *Lookups) Me(ctx context.Context) (*api.User, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.me == nil {
+		me, err := l.client.GetMe(ctx)
+		if err != nil {
+			return nil, err
+		}
+		l.me = me
+	}
+	return l.me, nil
+}
+
+// PublicKey returns the armored public key of a user, the keys of all users are fetched on first use
+// and fetched again when the user is unknown, such as a user created during the same apply.
+func (l *Lookups) PublicKey(ctx context.Context, userID string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if key, ok := l.publicKeys[userID]; ok {
+		return key, nil
+	}
+
+	users, err := l.client.GetUsers(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	l.publicKeys = make(map[string]string, len(users))
+	for _, user := range users {
+		if user.GPGKey != nil {
+			l.publicKeys[user.ID] = user.GPGKey.ArmoredKey
+		}
+	}
+
+	key, ok := l.publicKeys[userID]
+	if !ok {
+		return "", fmt.Errorf("cannot find the public key of user %s", userID)
+	}
+	return key, nil
+}
+
+// InvalidateFolders drops the memoized folders, it must be called after creating, moving or deleting a folder.
+func (l *Lookups) InvalidateFolders() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.folders = nil
+}
+
+// InvalidateGroups drops the memoized groups, it must be called after creating, renaming or deleting a group.
+func (l *Lookups) InvalidateGroups() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.groups = nil
+}
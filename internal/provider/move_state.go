@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// movedPasswordTypes are the resource types of other Passbolt providers that hold a password resource.
+var movedPasswordTypes = []string{"passbolt_password", "passbolt_resource"}
+
+// movedFolderTypes are the resource types of other Passbolt providers that hold a folder.
+var movedFolderTypes = []string{"passbolt_folder"}
+
+// movedAttribute maps an attribute of this provider to the attribute names used by other providers.
+type movedAttribute struct {
+	Target  string
+	Sources []string
+}
+
+// moveRawState copies the string attributes of a source resource into the target state.
+// The source state is decoded without a schema so that any provider version can be moved.
+func moveRawState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse, sourceTypes []string, attributes []movedAttribute) {
+	if req.SourceRawState == nil || !isMovableType(req.SourceTypeName, sourceTypes) {
+		return
+	}
+
+	var source map[string]interface{}
+	err := json.Unmarshal(req.SourceRawState.JSON, &source)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			"Could not decode the state of "+req.SourceTypeName+" from "+req.SourceProviderAddress+": "+err.Error(),
+		)
+		return
+	}
+
+	for _, attribute := range attributes {
+		for _, name := range attribute.Sources {
+			value, ok := source[name].(string)
+			if !ok || value == "" {
+				continue
+			}
+
+			resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root(attribute.Target), types.StringValue(value))...)
+			break
+		}
+	}
+}
+
+// isMovableType checks if a source resource type name is one of the supported types.
+func isMovableType(typeName string, sourceTypes []string) bool {
+	for _, sourceType := range sourceTypes {
+		if strings.EqualFold(typeName, sourceType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &OversharedPasswordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &OversharedPasswordsDataSource{}
+)
+
+// NewOversharedPasswordsDataSource is a helper function to simplify the provider implementation.
+func NewOversharedPasswordsDataSource() datasource.DataSource {
+	return &OversharedPasswordsDataSource{}
+}
+
+// OversharedPasswordsDataSource is the data source implementation.
+type OversharedPasswordsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// OversharedPasswordsDataSourceModel describes the data source data model.
+type OversharedPasswordsDataSourceModel struct {
+	BroadGroups   []types.String            `tfsdk:"broad_groups"`
+	MaxPrincipals types.Int64               `tfsdk:"max_principals"`
+	Passwords     []OversharedPasswordModel `tfsdk:"passwords"`
+}
+
+// OversharedPasswordModel describes a single over-shared password resource.
+type OversharedPasswordModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Principals  types.Int64    `tfsdk:"principals"`
+	BroadGroups []types.String `tfsdk:"broad_groups"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *OversharedPasswordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *OversharedPasswordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_overshared_passwords"
+}
+
+// Schema defines the schema for the data source.
+func (d *OversharedPasswordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"broad_groups": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The names of the groups that are too broad to share passwords with, such as an everyone group",
+			},
+			"max_principals": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Flag the password resources shared with more than this number of users and groups, not checked when not set",
+			},
+			"passwords": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of over-shared password resources, sorted by name then id",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the password resource",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the password resource",
+						},
+						"principals": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of users and groups the password resource is shared with",
+						},
+						"broad_groups": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The broad groups the password resource is shared with, sorted by name",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *OversharedPasswordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_overshared_passwords", &resp.Diagnostics) {
+		return
+	}
+
+	var state OversharedPasswordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(state.BroadGroups) == 0 && state.MaxPrincipals.IsNull() {
+		resp.Diagnostics.AddError("Validation Error", "At least one of broad_groups or max_principals must be set")
+		return
+	}
+	if state.MaxPrincipals.ValueInt64() < 0 {
+		resp.Diagnostics.AddError("Validation Error", "max_principals cannot be negative")
+		return
+	}
+
+	broadGroups := make(map[string]bool, len(state.BroadGroups))
+	for _, name := range state.BroadGroups {
+		broadGroups[name.ValueString()] = true
+	}
+
+	// Get all resources with the users and groups they are shared with
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/resources.json", nil, getResourcesOptions{
+		ContainPermissions:      true,
+		ContainPermissionsGroup: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading passwords",
+			"Could not read passwords, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var resources []resourceWithPermissions
+	err = json.Unmarshal(msg.Body, &resources)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading passwords",
+			"Could not decode passwords, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.Passwords = make([]OversharedPasswordModel, 0)
+	for _, resource := range resources {
+		flagged := make([]string, 0)
+		for _, permission := range resource.Permissions {
+			if permission.Group != nil && broadGroups[permission.Group.Name] {
+				flagged = append(flagged, permission.Group.Name)
+			}
+		}
+		sort.Strings(flagged)
+
+		principals := int64(len(resource.Permissions))
+		tooMany := !state.MaxPrincipals.IsNull() && principals > state.MaxPrincipals.ValueInt64()
+		if len(flagged) == 0 && !tooMany {
+			continue
+		}
+
+		password := OversharedPasswordModel{
+			ID:          types.StringValue(resource.ID),
+			Name:        types.StringValue(resource.Name),
+			Principals:  types.Int64Value(principals),
+			BroadGroups: make([]types.String, 0, len(flagged)),
+		}
+		for _, name := range flagged {
+			password.BroadGroups = append(password.BroadGroups, types.StringValue(name))
+		}
+		state.Passwords = append(state.Passwords, password)
+	}
+	sort.SliceStable(state.Passwords, func(i, j int) bool {
+		if state.Passwords[i].Name.ValueString() != state.Passwords[j].Name.ValueString() {
+			return state.Passwords[i].Name.ValueString() < state.Passwords[j].Name.ValueString()
+		}
+		return state.Passwords[i].ID.ValueString() < state.Passwords[j].ID.ValueString()
+	})
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// deleteBlockers is the body of a failed delete dry run, listing what the deleted user or group is the only owner
+// or manager of.
+type deleteBlockers struct {
+	Errors struct {
+		Resources struct {
+			SoleOwner []ownedItem `json:"sole_owner"`
+		} `json:"resources"`
+		Folders struct {
+			SoleOwner []ownedItem `json:"sole_owner"`
+		} `json:"folders"`
+		Groups struct {
+			SoleManager []api.Group `json:"sole_manager"`
+		} `json:"groups"`
+	} `json:"errors"`
+}
+
+// ownedItem is a resource or folder with its permissions, as listed by a delete dry run.
+type ownedItem struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Permissions []api.Permission `json:"permissions"`
+}
+
+// ownershipTransfer is the transfer part of a delete request.
+type ownershipTransfer struct {
+	Owners   []ownerTransfer   `json:"owners,omitempty"`
+	Managers []managerTransfer `json:"managers,omitempty"`
+}
+
+// ownerTransfer designates the permission becoming owner of a resource or folder.
+type ownerTransfer struct {
+	ID            string `json:"id"`
+	ACOForeignKey string `json:"aco_foreign_key"`
+}
+
+// managerTransfer designates the membership becoming manager of a group.
+type managerTransfer struct {
+	ID      string `json:"id"`
+	GroupID string `json:"group_id"`
+}
+
+// deleteRequest is the body of a delete request transferring ownership.
+type deleteRequest struct {
+	Transfer ownershipTransfer `json:"transfer"`
+}
+
+// deleteWithTransfer deletes a user or group, transferring the ownership of everything it solely owns, and for users the
+// management of the groups they solely manage, to the user or group with the given ID. The path is the delete endpoint,
+// such as /groups/<id>, a dry run tells what has to be transferred.
+func deleteWithTransfer(ctx context.Context, client *api.Client, path, targetID string) error {
+	msg, err := doCustomRequest(ctx, client, "DELETE", path+"/dry-run.json", nil, nil)
+	if err == nil {
+		_, err = doCustomRequest(ctx, client, "DELETE", path+".json", nil, nil)
+		return err
+	}
+	if msg == nil || len(msg.Body) == 0 {
+		return err
+	}
+
+	var blockers deleteBlockers
+	if json.Unmarshal(msg.Body, &blockers) != nil {
+		return err
+	}
+
+	items := append(blockers.Errors.Resources.SoleOwner, blockers.Errors.Folders.SoleOwner...)
+	groups := blockers.Errors.Groups.SoleManager
+	if len(items) == 0 && len(groups) == 0 {
+		return err
+	}
+
+	transfer, err := ownerTransfers(items, targetID)
+	if err != nil {
+		return err
+	}
+
+	transfer.Managers, err = managerTransfers(groups, targetID)
+	if err != nil {
+		return err
+	}
+
+	_, err = doCustomRequest(ctx, client, "DELETE", path+".json", deleteRequest{Transfer: transfer}, nil)
+	return err
+}
+
+// ownerTransfers picks the permission of the target on every item, the target must already have access to all of them.
+func ownerTransfers(items []ownedItem, targetID string) (ownershipTransfer, error) {
+	var transfer ownershipTransfer
+	var missing []string
+	for _, item := range items {
+		found := false
+		for _, permission := range item.Permissions {
+			if permission.AROForeignKey == targetID {
+				transfer.Owners = append(transfer.Owners, ownerTransfer{
+					ID:            permission.ID,
+					ACOForeignKey: item.ID,
+				})
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, fmt.Sprintf("'%s' (%s)", item.Name, item.ID))
+		}
+	}
+
+	if len(missing) > 0 {
+		return transfer, fmt.Errorf("ownership can only be transferred to a user or group that already has access, %s has no access to %s",
+			targetID, strings.Join(missing, ", "))
+	}
+	return transfer, nil
+}
+
+// managerTransfers picks the membership of the target in every group, the target must already be a member of all of them.
+func managerTransfers(groups []api.Group, targetID string) ([]managerTransfer, error) {
+	var transfers []managerTransfer
+	var missing []string
+	for _, group := range groups {
+		found := false
+		for _, membership := range group.GroupUsers {
+			if membership.UserID == targetID {
+				transfers = append(transfers, managerTransfer{
+					ID:      membership.ID,
+					GroupID: group.ID,
+				})
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, fmt.Sprintf("'%s' (%s)", group.Name, group.ID))
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("group management can only be transferred to a member, %s is not a member of %s",
+			targetID, strings.Join(missing, ", "))
+	}
+	return transfers, nil
+}
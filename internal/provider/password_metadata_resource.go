@@ -0,0 +1,443 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// cleartextDescriptionType is the only resource type storing the description outside of the encrypted secret.
+const cleartextDescriptionType = "password-string"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &PasswordMetadataResource{}
+	_ resource.ResourceWithConfigure   = &PasswordMetadataResource{}
+	_ resource.ResourceWithImportState = &PasswordMetadataResource{}
+	_ resource.ResourceWithModifyPlan  = &PasswordMetadataResource{}
+)
+
+// NewPasswordMetadataResource is a helper function to simplify the provider implementation.
+func NewPasswordMetadataResource() resource.Resource {
+	return &PasswordMetadataResource{}
+}
+
+// PasswordMetadataResource is the resource implementation.
+type PasswordMetadataResource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// PasswordMetadataResourceModel describes the resource data model.
+type PasswordMetadataResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	ResourceID     types.String   `tfsdk:"resource_id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	FolderParentID types.String   `tfsdk:"folder_parent_id"`
+	Shares         types.Set      `tfsdk:"shares"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *PasswordMetadataResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.data = data
+}
+
+// Metadata returns the resource type name.
+func (r *PasswordMetadataResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password_metadata"
+}
+
+// Schema defines the schema for the resource.
+func (r *PasswordMetadataResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The metadata, placement and shares of an existing password resource whose secret is managed elsewhere. " +
+			"The secret is never read back into the state nor changed, and destroying only stops managing the password resource",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the password resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the existing password resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the password resource",
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Description: "The description of the password resource. Only resources of the password-string type keep it outside of the secret, " +
+					"it cannot be managed for the other types",
+			},
+			"folder_parent_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique identifier of the folder to move the password resource to, the current folder is kept when unset",
+			},
+			"shares": schema.SetNestedAttribute{
+				Optional:    true,
+				Description: "The groups to share the password resource with, permissions of other users and groups are left untouched",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the group",
+						},
+						"permission": schema.StringAttribute{
+							Required:    true,
+							Description: "The permission granted to the group, one of read, update or owner",
+							Validators: []validator.String{
+								stringvalidator.OneOf(permissionNames()...),
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create takes over the metadata of the existing resource and sets the initial Terraform state.
+func (r *PasswordMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_password_metadata", &resp.Diagnostics) {
+		return
+	}
+
+	var plan PasswordMetadataResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.apply(ctx, nil, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.ResourceID
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PasswordMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_password_metadata", &resp.Diagnostics) {
+		return
+	}
+
+	var state PasswordMetadataResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	current, err := r.client.GetResource(ctx, state.ResourceID.ValueString())
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		current, err = r.client.GetResource(ctx, state.ResourceID.ValueString())
+	}
+	if err != nil {
+		// The password resource was deleted outside of Terraform
+		if isResourceNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading password",
+			"Could not read password, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(current.Name)
+	state.FolderParentID = folderIDValue(current.FolderParentID)
+
+	// Descriptions kept in the secret are not read, that would mean decrypting it
+	if !state.Description.IsNull() {
+		resourceType, err := r.client.GetResourceType(ctx, current.ResourceTypeID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading password type",
+				"Could not read password type, unexpected error: "+describeError(err),
+			)
+			return
+		}
+		if resourceType.Slug == cleartextDescriptionType {
+			state.Description = types.StringValue(current.Description)
+		}
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the metadata and sets the updated Terraform state on success.
+func (r *PasswordMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_password_metadata", &resp.Diagnostics) {
+		return
+	}
+
+	var plan PasswordMetadataResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state PasswordMetadataResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.apply(ctx, &state, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete only removes the resource from the Terraform state, the password resource is owned by another system.
+func (r *PasswordMetadataResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ImportState imports an existing password resource by its ID.
+func (r *PasswordMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_id"), req.ID)...)
+}
+
+// ModifyPlan fails at plan time when a folder is set on a server without folders.
+func (r *PasswordMetadataResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var folderParentID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("folder_parent_id"), &folderParentID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !folderParentID.IsNull() {
+		requirePlugin(r.data, pluginFolders, "folder_parent_id", &resp.Diagnostics)
+	}
+}
+
+// apply brings the metadata, folder and shares of the password resource in line with the plan, the state is nil on creation.
+func (r *PasswordMetadataResource) apply(ctx context.Context, state, plan *PasswordMetadataResourceModel, diags *diag.Diagnostics) {
+	resourceID := plan.ResourceID.ValueString()
+	current, err := r.client.GetResource(ctx, resourceID)
+	if err != nil {
+		diags.AddError(
+			"Error reading password",
+			"Could not read password, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	resourceType, err := r.client.GetResourceType(ctx, current.ResourceTypeID)
+	if err != nil {
+		diags.AddError(
+			"Error reading password type",
+			"Could not read password type, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	manageDescription := !plan.Description.IsNull()
+	if manageDescription && resourceType.Slug != cleartextDescriptionType {
+		diags.AddAttributeError(
+			path.Root("description"),
+			"Description kept in the secret",
+			fmt.Sprintf("Password resource '%s' is of type %s, which stores the description in the encrypted secret. "+
+				"Remove description from the configuration, it cannot be changed without touching the secret.", current.Name, resourceType.Slug),
+		)
+		return
+	}
+
+	// Only the metadata is sent, without secrets the server leaves the secret untouched
+	if current.Name != plan.Name.ValueString() || manageDescription && current.Description != plan.Description.ValueString() {
+		updated := api.Resource{
+			ID:             resourceID,
+			ResourceTypeID: current.ResourceTypeID,
+			Name:           plan.Name.ValueString(),
+			Username:       current.Username,
+			URI:            current.URI,
+			Description:    current.Description,
+		}
+		if manageDescription {
+			updated.Description = plan.Description.ValueString()
+		}
+
+		_, err = r.client.UpdateResource(ctx, resourceID, updated)
+		if err != nil {
+			diags.AddError(
+				"Error updating password",
+				"Could not update password, unexpected error: "+describeError(err),
+			)
+			return
+		}
+	}
+
+	folderID := current.FolderParentID
+	if !plan.FolderParentID.IsUnknown() && !plan.FolderParentID.IsNull() && plan.FolderParentID.ValueString() != folderID {
+		folderID = plan.FolderParentID.ValueString()
+		err = r.client.MoveResource(ctx, resourceID, folderID)
+		if err != nil {
+			diags.AddError(
+				"Error moving password",
+				"Could not move password, unexpected error: "+describeError(err),
+			)
+			return
+		}
+	}
+	plan.FolderParentID = folderIDValue(folderID)
+
+	r.updateShares(ctx, resourceID, state, *plan, diags)
+}
+
+// updateShares applies the planned shares, only the groups that were shared by Terraform before are unshared.
+func (r *PasswordMetadataResource) updateShares(ctx context.Context, resourceID string, state *PasswordMetadataResourceModel, plan PasswordMetadataResourceModel, diags *diag.Diagnostics) {
+	var previous, planned []PasswordShareModel
+	if state != nil && !state.Shares.IsNull() && !state.Shares.IsUnknown() {
+		diags.Append(state.Shares.ElementsAs(ctx, &previous, false)...)
+	}
+	if !plan.Shares.IsNull() && !plan.Shares.IsUnknown() {
+		diags.Append(plan.Shares.ElementsAs(ctx, &planned, false)...)
+	}
+	if diags.HasError() || len(previous) == 0 && len(planned) == 0 {
+		return
+	}
+
+	groupIDs, err := getGroupIDs(ctx, r.data.Lookups)
+	if err != nil {
+		diags.AddError("Cannot get groups", err.Error())
+		return
+	}
+
+	plannedOperations, err := resolveShares(planned, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return
+	}
+
+	// Groups that were deleted in the meantime cannot be unshared anymore
+	var existing []PasswordShareModel
+	for _, share := range previous {
+		if _, ok := groupIDs[share.Group.ValueString()]; ok {
+			existing = append(existing, share)
+		}
+	}
+	previousOperations, err := resolveShares(existing, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return
+	}
+
+	permissions, err := r.client.GetResourcePermissions(ctx, resourceID)
+	if err != nil {
+		diags.AddError("Cannot get resource permissions", err.Error())
+		return
+	}
+
+	// Sharing encrypts the existing secret for the users gaining access, its value is not changed
+	changes := shareChanges(permissions, previousOperations, plannedOperations)
+	if len(changes) == 0 {
+		return
+	}
+
+	err = shareResource(ctx, r.data, resourceID, changes)
+	if err != nil {
+		diags.AddError("Cannot share resource", err.Error())
+		return
+	}
+}
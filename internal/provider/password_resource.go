@@ -2,20 +2,65 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 )
 
+// Field length limits of the Passbolt resources table.
+const (
+	maxNameLength     = 255
+	maxUsernameLength = 255
+	maxURILength      = 1024
+)
+
+// uriPattern is the format URIs of password resources are expected to have.
+var uriPattern = regexp.MustCompile(`^https?://.*`)
+
+// Bounds of generate_password_length.
+const (
+	minGeneratedPasswordLength = 12
+	maxGeneratedPasswordLength = 1024
+)
+
+// generatedPasswordAlphabet are the characters of generated passwords, quotes, backslashes and spaces are left out
+// so that the passwords can be pasted into shells and configuration files as they are.
+const generatedPasswordAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&()*+,-./:;<=>?@[]^_{|}~"
+
+// Reads of a resource right after its creation are retried this many times, starting with this delay.
+const (
+	createdResourceAttempts = 5
+	createdResourceDelay    = 250 * time.Millisecond
+)
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &PasswordResource{}
-	_ resource.ResourceWithConfigure = &PasswordResource{}
+	_ resource.Resource                 = &PasswordResource{}
+	_ resource.ResourceWithConfigure    = &PasswordResource{}
+	_ resource.ResourceWithMoveState    = &PasswordResource{}
+	_ resource.ResourceWithModifyPlan   = &PasswordResource{}
+	_ resource.ResourceWithUpgradeState = &PasswordResource{}
 )
 
 // NewPasswordResource is a helper function to simplify the provider implementation.
@@ -26,18 +71,33 @@ func NewPasswordResource() resource.Resource {
 // PasswordResource is the resource implementation.
 type PasswordResource struct {
 	client *api.Client
+	data   *PassboltProviderData
 }
 
 // PasswordResourceModel describes the resource data model.
 type PasswordResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Username     types.String `tfsdk:"username"`
-	URI          types.String `tfsdk:"uri"`
-	Password     types.String `tfsdk:"password"`
-	FolderParent types.String `tfsdk:"folder_parent"`
-	ShareGroup   types.String `tfsdk:"share_group"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Username           types.String   `tfsdk:"username"`
+	URI                types.String   `tfsdk:"uri"`
+	Password           types.String   `tfsdk:"password"`
+	GenerateLength     types.Int64    `tfsdk:"generate_password_length"`
+	FolderParent       types.String   `tfsdk:"folder_parent"`
+	FolderParentID     types.String   `tfsdk:"folder_parent_id"`
+	ShareGroup         types.String   `tfsdk:"share_group"`
+	Shares             types.Set      `tfsdk:"shares"`
+	Modified           types.String   `tfsdk:"modified"`
+	Personal           types.Bool     `tfsdk:"personal"`
+	Owners             types.List     `tfsdk:"owners"`
+	PermissionIDs      types.Map      `tfsdk:"share_permission_ids"`
+	Favorite           types.Bool     `tfsdk:"favorite"`
+	Tags               types.Set      `tfsdk:"tags"`
+	AdditiveTags       types.Bool     `tfsdk:"additive_tags"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	InheritFolder      types.Bool     `tfsdk:"inherit_folder_permissions"`
+	WaitForPermissions types.Bool     `tfsdk:"wait_for_share_permissions"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -46,16 +106,17 @@ func (r *PasswordResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*PassboltProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.data = data
 }
 
 // Metadata returns the resource type name.
@@ -64,8 +125,9 @@ func (r *PasswordResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *PasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *PasswordResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -74,6 +136,9 @@ func (r *PasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the password resource",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(maxNameLength),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
@@ -82,30 +147,151 @@ func (r *PasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"username": schema.StringAttribute{
 				Required:    true,
 				Description: "The username for the password resource",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(maxUsernameLength),
+				},
 			},
 			"uri": schema.StringAttribute{
 				Required:    true,
 				Description: "The URI for the password resource",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(maxURILength),
+				},
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "The password for the resource",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Description: "The password for the resource. When generate_password_length is set instead, this is the generated password, " +
+					"which can be referenced by other resources. Like a configured password it is stored in the Terraform state, " +
+					"so the state must be protected accordingly",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("generate_password_length")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"generate_password_length": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("Generate a random password of this length with lowercase and uppercase letters, digits and symbols, "+
+					"instead of setting password (between %d and %d). Changing it generates a new password", minGeneratedPasswordLength, maxGeneratedPasswordLength),
+				Validators: []validator.Int64{
+					int64validator.Between(minGeneratedPasswordLength, maxGeneratedPasswordLength),
+				},
 			},
 			"folder_parent": schema.StringAttribute{
+				Optional:           true,
+				Description:        "The name of the parent folder. Folder names are not unique, the first folder with this name is used",
+				DeprecationMessage: deprecatedAttributeMessage("folder_parent", "folder_parent_id"),
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("folder_parent_id")),
+				},
+			},
+			"folder_parent_id": schema.StringAttribute{
 				Optional:    true,
-				Description: "The name of the parent folder",
+				Computed:    true,
+				Description: "The unique identifier of the parent folder",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("folder_parent")),
+				},
 			},
 			"share_group": schema.StringAttribute{
+				Optional:           true,
+				Description:        "The name of the group to share the resource with, the group is granted the update permission",
+				DeprecationMessage: deprecatedAttributeMessage("share_group", "shares"),
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("shares")),
+				},
+			},
+			"favorite": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the password resource is a favorite of the authenticated user",
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The slugs of the tags of the password resource, shared tags start with #. " +
+					"When set, tags added or removed in the web interface show as changes and are reverted on the next apply. " +
+					"Leave unset when tags are managed outside of Terraform",
+			},
+			"additive_tags": schema.BoolAttribute{
+				Optional: true,
+				Description: "Only add the tags listed in tags and never remove tags added outside of Terraform, such as in the web interface. " +
+					"Tags removed from tags are still removed from the password resource",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "On creation, take over an existing password resource with the same name in the same folder and update it, instead of creating a duplicate",
+			},
+			"inherit_folder_permissions": schema.BoolAttribute{
+				Optional: true,
+				Description: "When the password resource is created, also grant the current permissions of its parent folder, like the web interface does. " +
+					"Shares set in shares take precedence, the inherited permissions are not managed afterwards",
+			},
+			"wait_for_share_permissions": schema.BoolAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("After sharing, wait up to %s until the permission of every group shared with is recorded on the resource. ", sharePermissionsTimeout) +
+					"Only the permissions are checked: Passbolt does not expose the secrets of other users, so whether group members can already decrypt the secret is not verified",
+			},
+			"modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the password resource was last modified in Passbolt, used by detect_concurrent_changes",
+			},
+			"personal": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the password resource is only accessible to a single user",
+			},
+			"owners": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The usernames and group names with the owner permission on the password resource, sorted",
+			},
+			"share_permission_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The unique identifiers of the permissions of the groups the password resource is shared with, keyed by group name",
+			},
+			"shares": schema.SetNestedAttribute{
 				Optional:    true,
-				Description: "The name of the group to share the resource with",
+				Computed:    true,
+				Description: "The groups to share the resource with. Defaults to the group set in share_group",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the group",
+						},
+						"permission": schema.StringAttribute{
+							Required:    true,
+							Description: "The permission granted to the group, one of read, update or owner",
+							Validators: []validator.String{
+								stringvalidator.OneOf(permissionNames()...),
+							},
+						},
+					},
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *PasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_password", &resp.Diagnostics) {
+		return
+	}
+
 	var plan PasswordResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -113,6 +299,25 @@ func (r *PasswordResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Generate the password first, everything below uses it like a configured one
+	if plan.Password.IsUnknown() {
+		password, err := generatePassword(int(plan.GenerateLength.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot generate password", err.Error())
+			return
+		}
+		plan.Password = types.StringValue(password)
+	}
+
 	// Validate input
 	if plan.Name.ValueString() == "" {
 		resp.Diagnostics.AddError("Validation Error", "Name cannot be empty")
@@ -131,17 +336,10 @@ func (r *PasswordResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Validate URI format
-	uri := plan.URI.ValueString()
-	if !regexp.MustCompile(`^https?://.*`).MatchString(uri) {
-		resp.Diagnostics.AddError("Validation Error", "URI must be a valid HTTP or HTTPS URL")
-		return
-	}
-
 	// Get folder ID if specified
-	var folderID string
+	folderID := configuredFolderID(plan)
 	if !plan.FolderParent.IsNull() && !plan.FolderParent.IsUnknown() {
-		folders, err := r.client.GetFolders(ctx, nil)
+		folders, err := r.data.Lookups.Folders(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError("Cannot get folders", err.Error())
 			return
@@ -160,14 +358,58 @@ func (r *PasswordResource) Create(ctx context.Context, req resource.CreateReques
 		}
 	}
 
+	// Repeated pipeline runs against an existing vault tend to create duplicates
+	var existing *api.Resource
+	if r.data.WarnOnDuplicates || plan.AdoptExisting.ValueBool() {
+		var err error
+		existing, err = r.findExistingResource(ctx, folderID, plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot get resources", err.Error())
+			return
+		}
+
+		if existing != nil && !plan.AdoptExisting.ValueBool() {
+			resp.Diagnostics.AddWarning(
+				"Duplicate password resource",
+				fmt.Sprintf("A password resource named '%s' already exists in the same folder (%s), creating another one. "+
+					"Use terraform import to manage the existing resource instead.", plan.Name.ValueString(), existing.ID),
+			)
+		}
+	}
+
+	// Resolve the groups to share with before creating anything
+	shareOperations := r.plannedShareOperations(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isCancelled(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	if existing != nil && plan.AdoptExisting.ValueBool() {
+		r.adoptResource(ctx, plan, existing.ID, shareOperations, resp)
+		return
+	}
+
+	// The folder permissions are only copied onto new resources, adopted ones keep theirs
+	if plan.InheritFolder.ValueBool() {
+		inherited, err := r.folderShareOperations(ctx, folderID, shareOperations)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot get folder permissions", err.Error())
+			return
+		}
+		shareOperations = append(shareOperations, inherited...)
+	}
+
 	// Create the resource using the helper
 	resourceID, err := helper.CreateResource(
 		ctx,
 		r.client,
 		folderID,
 		plan.Name.ValueString(),
-		plan.Username.ValueString(),
-		plan.URI.ValueString(),
+		r.normalize(plan.Username.ValueString(), normalizeUsername),
+		r.normalize(plan.URI.ValueString(), normalizeURI),
 		plan.Password.ValueString(),
 		plan.Description.ValueString(),
 	)
@@ -176,41 +418,63 @@ func (r *PasswordResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Share with group if specified
-	if !plan.ShareGroup.IsNull() && !plan.ShareGroup.IsUnknown() {
-		groups, err := r.client.GetGroups(ctx, nil)
+	// Record the resource before the following steps, a failure then leaves it in the state instead of orphaned
+	// and Terraform replaces it on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, createdResourceState(plan, resourceID, folderID))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = waitForCreatedResource(ctx, r.client, resourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read created resource", err.Error())
+		r.rollbackFailedCreate(ctx, resourceID, plan.Name.ValueString(), resp)
+		return
+	}
+
+	// Share with groups if specified
+	shared := false
+	if len(shareOperations) > 0 {
+		err = r.share(ctx, plan, resourceID, shareOperations)
 		if err != nil {
-			resp.Diagnostics.AddError("Cannot get groups", err.Error())
+			resp.Diagnostics.AddError("Cannot share resource", err.Error())
+			r.rollbackFailedCreate(ctx, resourceID, plan.Name.ValueString(), resp)
 			return
 		}
-
-		var groupID string
-		for _, group := range groups {
-			if group.Name == plan.ShareGroup.ValueString() {
-				groupID = group.ID
-				break
-			}
+		// Permissions inherited from the folder may only grant users, which leaves the resource unshared
+		for _, operation := range shareOperations {
+			shared = shared || operation.ARO == "Group"
 		}
+	}
 
-		if groupID != "" {
-			shares := []helper.ShareOperation{
-				{
-					Type:  7, // Read permission
-					ARO:   "Group",
-					AROID: groupID,
-				},
-			}
+	// Make sure the resource did not end up in the personal space only
+	if r.data.RequireShared && !shared {
+		// The deleted resource must not stay in the state recorded after its creation
+		if r.removeUnsharedResource(ctx, resourceID, plan.Name.ValueString(), &resp.Diagnostics) {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
 
-			err = helper.ShareResource(ctx, r.client, resourceID, shares)
-			if err != nil {
-				resp.Diagnostics.AddError("Cannot share resource", err.Error())
-				return
-			}
+	// Mark as favorite if requested
+	if plan.Favorite.ValueBool() {
+		r.setFavorite(ctx, resourceID, "", true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			r.rollbackFailedCreate(ctx, resourceID, plan.Name.ValueString(), resp)
+			return
 		}
 	}
 
+	r.applyTags(ctx, resourceID, types.SetNull(types.StringType), plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		r.rollbackFailedCreate(ctx, resourceID, plan.Name.ValueString(), resp)
+		return
+	}
+
 	// Set the computed values
 	plan.ID = types.StringValue(resourceID)
+	plan.FolderParentID = folderIDValue(folderID)
+	r.setComputedAttributes(ctx, resourceID, &plan)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -222,6 +486,10 @@ func (r *PasswordResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read refreshes the Terraform state with the latest data.
 func (r *PasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_password", &resp.Diagnostics) {
+		return
+	}
+
 	var state PasswordResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -229,8 +497,20 @@ func (r *PasswordResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	// Get the resource from Passbolt
-	resource, err := r.client.GetResource(ctx, state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get the resource from Passbolt, along with everything else the state is read from
+	view, err := r.getResourceView(ctx, state.ID.ValueString())
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		view, err = r.getResourceView(ctx, state.ID.ValueString())
+	}
 	if err != nil {
 		// Check if the resource doesn't exist (was deleted outside of Terraform)
 		if isResourceNotFoundError(err) {
@@ -242,32 +522,55 @@ func (r *PasswordResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 		resp.Diagnostics.AddError(
 			"Error reading password",
-			"Could not read password, unexpected error: "+err.Error(),
+			"Could not read password, unexpected error: "+describeError(err),
 		)
 		return
 	}
+	resource := view.Resource
 
 	// Update the state with the current values from Passbolt
 	state.Name = types.StringValue(resource.Name)
 	state.Description = types.StringValue(resource.Description)
-	state.Username = types.StringValue(resource.Username)
-	state.URI = types.StringValue(resource.URI)
+	state.Username = r.refreshed(state.Username, resource.Username, normalizeUsername)
+	state.URI = r.refreshed(state.URI, resource.URI, normalizeURI)
+	state.Modified = modifiedValue(&resource)
+	setPermissionAttributes(view.Permissions, &state)
+	state.Favorite = types.BoolValue(favoriteID(view) != "")
+
+	// Only track tags when they are managed
+	if !state.Tags.IsNull() {
+		current := sortedTagSlugs(resource.Tags)
+		if state.AdditiveTags.ValueBool() {
+			// Tags added outside of Terraform are ignored, only the declared ones are tracked
+			var present []string
+			for _, tag := range setToStrings(ctx, state.Tags, &resp.Diagnostics) {
+				if containsString(current, tag) {
+					present = append(present, tag)
+				}
+			}
+			current = present
+		}
+		state.Tags = stringsToSet(current, &resp.Diagnostics)
+	}
 
 	// Note: Passwords cannot be read back from Passbolt for security reasons
 	// We keep the password from the state to avoid losing it
 
-	// Get folder information if available
-	if resource.FolderParentID != "" {
-		folders, err := r.client.GetFolders(ctx, nil)
+	// The parent folder comes with the resource, servers leaving it out of the response fall back to reading it
+	// Resources placed with folder_parent_id keep folder_parent unset
+	if resource.FolderParentID != "" && (!state.FolderParent.IsNull() || state.FolderParentID.IsNull()) {
+		folder := view.Folder
+		if folder == nil {
+			folder, err = r.client.GetFolder(ctx, resource.FolderParentID, nil)
+		}
 		if err == nil {
-			for _, folder := range folders {
-				if folder.ID == resource.FolderParentID {
-					state.FolderParent = types.StringValue(folder.Name)
-					break
-				}
-			}
+			state.FolderParent = types.StringValue(folder.Name)
 		}
+	} else if resource.FolderParentID == "" {
+		// Moved to the root outside of Terraform
+		state.FolderParent = types.StringNull()
 	}
+	state.FolderParentID = folderIDValue(resource.FolderParentID)
 
 	// Set the updated state
 	diags = resp.State.Set(ctx, state)
@@ -279,6 +582,10 @@ func (r *PasswordResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_password", &resp.Diagnostics) {
+		return
+	}
+
 	var plan PasswordResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -293,18 +600,60 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Generate the password first, everything below uses it like a configured one
+	if plan.Password.IsUnknown() {
+		password, err := generatePassword(int(plan.GenerateLength.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot generate password", err.Error())
+			return
+		}
+		plan.Password = types.StringValue(password)
+	}
+
 	// Get current resource to check what needs to be updated
-	currentResource, err := r.client.GetResource(ctx, state.ID.ValueString())
+	current, err := r.getResourceView(ctx, state.ID.ValueString())
+	deleted := false
 	if err != nil {
+		if !isResourceNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Error reading current resource",
+				"Could not read current resource, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		// The resource was deleted outside of Terraform since the plan, create it again
+		resp.Diagnostics.AddWarning(
+			"Resource deleted outside of Terraform",
+			fmt.Sprintf("Resource '%s' (%s) no longer exists and is created again.", state.Name.ValueString(), state.ID.ValueString()),
+		)
+		deleted = true
+		current = &passwordResourceView{}
+	}
+	currentResource := &current.Resource
+
+	// Do not overwrite changes made in Passbolt since the last refresh
+	if r.data.DetectConcurrentChanges && !deleted && !isUnchanged(state.Modified, currentResource) {
 		resp.Diagnostics.AddError(
-			"Error reading current resource",
-			"Could not read current resource, unexpected error: "+err.Error(),
+			"Resource modified outside of Terraform",
+			fmt.Sprintf("Resource '%s' (%s) was modified in Passbolt at %s since it was last read. "+
+				"Refresh the state and apply again to take these changes into account.",
+				state.Name.ValueString(), state.ID.ValueString(), modifiedValue(currentResource).ValueString()),
 		)
 		return
 	}
 
 	// Check if we need to recreate the resource
-	needsRecreation := false
+	needsRecreation := deleted
 	if plan.Name.ValueString() != currentResource.Name {
 		needsRecreation = true
 
@@ -313,11 +662,11 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 		needsRecreation = true
 
 	}
-	if plan.Username.ValueString() != currentResource.Username {
+	if r.normalize(plan.Username.ValueString(), normalizeUsername) != r.normalize(currentResource.Username, normalizeUsername) {
 		needsRecreation = true
 
 	}
-	if plan.URI.ValueString() != currentResource.URI {
+	if r.normalize(plan.URI.ValueString(), normalizeURI) != r.normalize(currentResource.URI, normalizeURI) {
 		needsRecreation = true
 
 	}
@@ -330,24 +679,24 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 	if plan.FolderParent.ValueString() != state.FolderParent.ValueString() {
 		needsRecreation = true
 
+	}
+	if configuredFolderID(plan) != "" && plan.FolderParentID.ValueString() != state.FolderParentID.ValueString() {
+		needsRecreation = true
+
 	}
 
-	// If we need to recreate, delete and create new resource
+	// If we need to recreate, create the new resource and delete the old one
 	if needsRecreation {
-		// Delete the old resource
-		err = r.client.DeleteResource(ctx, state.ID.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting old resource",
-				"Could not delete old resource, unexpected error: "+err.Error(),
-			)
+		// Resolve the groups to share with before touching the old resource
+		shareOperations := r.plannedShareOperations(ctx, plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		// Get folder ID if specified
-		var folderID string
+		// Resolve the folder before touching the old resource as well, a stale name must not move it to the root
+		folderID := configuredFolderID(plan)
 		if !plan.FolderParent.IsNull() && !plan.FolderParent.IsUnknown() {
-			folders, err := r.client.GetFolders(ctx, nil)
+			folders, err := r.data.Lookups.Folders(ctx)
 			if err != nil {
 				resp.Diagnostics.AddError("Cannot get folders", err.Error())
 				return
@@ -359,16 +708,54 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 					break
 				}
 			}
+
+			if folderID == "" {
+				folderParentNotFound(plan.FolderParent.ValueString(), state.FolderParentID.ValueString(), folders, &resp.Diagnostics)
+				return
+			}
+		}
+
+		// Keep the permissions granted outside of Terraform, they would be lost with the old resource
+		var preservedOperations []helper.ShareOperation
+		var metadata *resourceMetadata
+		if !deleted {
+			preservedOperations = r.preservedShareOperations(ctx, state, shareOperations, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Tags and comments are carried over on a best effort basis
+			metadata, err = getResourceMetadata(ctx, r.client, r.data, state.ID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Tags and comments not preserved",
+					fmt.Sprintf("Could not read the tags and comments of resource '%s', they will not be copied to the recreated resource: %s", state.ID.ValueString(), err.Error()),
+				)
+			}
+		}
+
+		if isCancelled(ctx, &resp.Diagnostics) {
+			return
+		}
+
+		// The recreated resource is new to its folder as well
+		if plan.InheritFolder.ValueBool() {
+			inherited, err := r.folderShareOperations(ctx, folderID, append(shareOperations, preservedOperations...))
+			if err != nil {
+				resp.Diagnostics.AddError("Cannot get folder permissions", err.Error())
+				return
+			}
+			preservedOperations = append(preservedOperations, inherited...)
 		}
 
-		// Create the new resource
+		// Create the new resource, the old one is only deleted once the new one is shared
 		resourceID, err := helper.CreateResource(
 			ctx,
 			r.client,
 			folderID,
 			plan.Name.ValueString(),
-			plan.Username.ValueString(),
-			plan.URI.ValueString(),
+			r.normalize(plan.Username.ValueString(), normalizeUsername),
+			r.normalize(plan.URI.ValueString(), normalizeURI),
 			plan.Password.ValueString(),
 			plan.Description.ValueString(),
 		)
@@ -377,41 +764,109 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 			return
 		}
 
-		// Share with group if specified
-		if !plan.ShareGroup.IsNull() && !plan.ShareGroup.IsUnknown() {
-			groups, err := r.client.GetGroups(ctx, nil)
+		// Record the new resource right away, like Create does, so a failure does not leave it orphaned
+		resp.Diagnostics.Append(resp.State.Set(ctx, createdResourceState(plan, resourceID, folderID))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// A failure before the old resource is deleted leaves both in Passbolt
+		keptOldResource := func() {
+			if deleted {
+				return
+			}
+			resp.Diagnostics.AddWarning(
+				"Old resource kept",
+				fmt.Sprintf("Resource '%s' (%s) was not deleted because its replacement %s could not be completed. "+
+					"Delete it in Passbolt once the replacement is in place.", state.Name.ValueString(), state.ID.ValueString(), resourceID),
+			)
+		}
+
+		err = waitForCreatedResource(ctx, r.client, resourceID)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot read recreated resource", err.Error())
+			keptOldResource()
+			return
+		}
+
+		// Share with groups if specified, along with the preserved permissions
+		operations := append(shareOperations, preservedOperations...)
+		shared := false
+		if len(operations) > 0 {
+			err = r.share(ctx, plan, resourceID, operations)
 			if err != nil {
-				resp.Diagnostics.AddError("Cannot get groups", err.Error())
+				resp.Diagnostics.AddError("Cannot share resource", err.Error())
+				keptOldResource()
 				return
 			}
+			for _, operation := range operations {
+				shared = shared || operation.ARO == "Group"
+			}
+		}
 
-			var groupID string
-			for _, group := range groups {
-				if group.Name == plan.ShareGroup.ValueString() {
-					groupID = group.ID
-					break
+		// Make sure the resource did not end up in the personal space only
+		if r.data.RequireShared && !shared {
+			// The deleted resource must not stay in the state, the old one takes its place again unless it is gone as well
+			if r.removeUnsharedResource(ctx, resourceID, plan.Name.ValueString(), &resp.Diagnostics) {
+				if deleted {
+					resp.State.RemoveResource(ctx)
+				} else {
+					resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 				}
 			}
+			return
+		}
 
-			if groupID != "" {
-				shares := []helper.ShareOperation{
-					{
-						Type:  7, // Read permission
-						ARO:   "Group",
-						AROID: groupID,
-					},
-				}
+		if metadata != nil {
+			err = restoreResourceMetadata(ctx, r.client, resourceID, metadata)
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Tags and comments not preserved",
+					fmt.Sprintf("Could not copy all tags and comments to the recreated resource '%s': %s", resourceID, err.Error()),
+				)
+			}
+		}
 
-				err = helper.ShareResource(ctx, r.client, resourceID, shares)
-				if err != nil {
-					resp.Diagnostics.AddError("Cannot share resource", err.Error())
-					return
-				}
+		// The new resource is in place, delete the old one unless it is already gone
+		if !deleted {
+			err = r.client.DeleteResource(ctx, state.ID.ValueString())
+			if err != nil && !isResourceNotFoundError(err) {
+				resp.Diagnostics.AddWarning(
+					"Error deleting old resource",
+					fmt.Sprintf("Resource '%s' (%s) was replaced by %s but could not be deleted, delete it in Passbolt. Unexpected error: %s",
+						state.Name.ValueString(), state.ID.ValueString(), resourceID, describeError(err)),
+				)
 			}
 		}
 
 		// Update the state ID
 		state.ID = types.StringValue(resourceID)
+		state.FolderParentID = folderIDValue(folderID)
+	} else if !plan.Shares.Equal(state.Shares) {
+		// Only the shares changed, update the permissions of the existing resource
+		r.updateShares(ctx, state, plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// A recreated resource is never a favorite yet
+	if needsRecreation && plan.Favorite.ValueBool() {
+		r.setFavorite(ctx, state.ID.ValueString(), "", true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if !needsRecreation && !plan.Favorite.Equal(state.Favorite) {
+		r.setFavorite(ctx, state.ID.ValueString(), favoriteID(current), plan.Favorite.ValueBool(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// A recreated resource got the old tags back, the planned ones are applied on top
+	r.applyTags(ctx, state.ID.ValueString(), state.Tags, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Update state with the new values from the plan
@@ -420,8 +875,17 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 	state.Username = plan.Username
 	state.URI = plan.URI
 	state.Password = plan.Password
+	state.GenerateLength = plan.GenerateLength
 	state.FolderParent = plan.FolderParent
 	state.ShareGroup = plan.ShareGroup
+	state.Shares = plan.Shares
+	state.Favorite = plan.Favorite
+	state.Tags = plan.Tags
+	state.AdditiveTags = plan.AdditiveTags
+	state.AdoptExisting = plan.AdoptExisting
+	state.InheritFolder = plan.InheritFolder
+	state.WaitForPermissions = plan.WaitForPermissions
+	r.setComputedAttributes(ctx, state.ID.ValueString(), &state)
 
 	// Set the updated state
 	diags = resp.State.Set(ctx, state)
@@ -431,22 +895,951 @@ func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 }
 
-// Delete deletes the resource and removes the Terraform state on success.
-func (r *PasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state PasswordResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// adoptResource takes over an existing resource, updating it to match the plan instead of creating a new one.
+// Permissions not in the plan are left untouched, as the resource was not managed by Terraform before.
+func (r *PasswordResource) adoptResource(ctx context.Context, plan PasswordResourceModel, resourceID string, shareOperations []helper.ShareOperation, resp *resource.CreateResponse) {
+	err := helper.UpdateResource(
+		ctx,
+		r.client,
+		resourceID,
+		plan.Name.ValueString(),
+		r.normalize(plan.Username.ValueString(), normalizeUsername),
+		r.normalize(plan.URI.ValueString(), normalizeURI),
+		plan.Password.ValueString(),
+		plan.Description.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot update adopted resource", err.Error())
 		return
 	}
 
-	// Delete the resource
-	err := r.client.DeleteResource(ctx, state.ID.ValueString())
+	existing, err := r.getResourceView(ctx, resourceID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting password",
-			"Could not delete password, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.AddError("Cannot read adopted resource", err.Error())
+		return
+	}
+	permissions := apiPermissions(existing.Permissions)
+
+	changes := shareChanges(permissions, nil, shareOperations)
+	if len(changes) > 0 {
+		err = r.share(ctx, plan, resourceID, changes)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot share resource", err.Error())
+			return
+		}
+	}
+
+	// The adopted resource is kept even when it is not shared, deleting it would lose data Terraform did not create
+	if r.data.RequireShared && len(shareOperations) == 0 {
+		shared := false
+		for _, permission := range permissions {
+			shared = shared || permission.ARO == "Group"
+		}
+		if !shared {
+			resp.Diagnostics.AddError(
+				"Resource not shared",
+				fmt.Sprintf("Adopted resource '%s' (%s) is not shared with any group and require_shared is enabled. "+
+					"Set shares to share it with a group.", plan.Name.ValueString(), resourceID),
+			)
+			return
+		}
+	}
+
+	r.setFavorite(ctx, resourceID, favoriteID(existing), plan.Favorite.ValueBool(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyTags(ctx, resourceID, types.SetNull(types.StringType), plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Adopting does not move the resource, it stays in the folder it was found in
+	plan.ID = types.StringValue(resourceID)
+	plan.FolderParentID = folderIDValue(existing.FolderParentID)
+	r.setComputedAttributes(ctx, resourceID, &plan)
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// findExistingResource returns the resource with the given name in the given folder, or nil if there is none.
+func (r *PasswordResource) findExistingResource(ctx context.Context, folderID, name string) (*api.Resource, error) {
+	var opts *api.GetResourcesOptions
+	if folderID != "" {
+		opts = &api.GetResourcesOptions{
+			FilterHasParent: []string{folderID},
+		}
+	}
+
+	resources, err := r.client.GetResources(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resources {
+		if resources[i].Name == name && resources[i].FolderParentID == folderID {
+			return &resources[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// removeUnsharedResource deletes a resource that could not be shared while require_shared is enabled,
+// and tells whether it was deleted.
+func (r *PasswordResource) removeUnsharedResource(ctx context.Context, resourceID, name string, diags *diag.Diagnostics) bool {
+	err := r.client.DeleteResource(ctx, resourceID)
+	if err != nil {
+		diags.AddError(
+			"Error deleting unshared resource",
+			fmt.Sprintf("Resource '%s' (%s) was not shared with any group and could not be deleted, unexpected error: %s", name, resourceID, describeError(err)),
+		)
+		return false
+	}
+
+	diags.AddError(
+		"Resource not shared",
+		fmt.Sprintf("Resource '%s' was not shared with any group and has been deleted because require_shared is enabled. "+
+			"Check that share_group or shares is set and matches an existing group.", name),
+	)
+	return true
+}
+
+// rollbackFailedCreate deletes a resource whose creation failed after it was stored in Passbolt when rollback_failed_creates
+// is enabled, so the failure does not leave an orphan secret behind. Otherwise the resource stays in the state.
+func (r *PasswordResource) rollbackFailedCreate(ctx context.Context, resourceID, name string, resp *resource.CreateResponse) {
+	if !r.data.RollbackFailedCreates {
+		resp.Diagnostics.AddWarning(
+			"Created resource kept",
+			fmt.Sprintf("Resource '%s' (%s) was created before the failure and is recorded in the state, it is replaced on the next apply. "+
+				"Enable rollback_failed_creates to delete such resources right away.", name, resourceID),
+		)
+		return
+	}
+
+	// The failure may be the expiry of the create timeout, the rollback gets its own
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultDeleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteResource(ctx, resourceID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rolling back created resource",
+			fmt.Sprintf("Resource '%s' (%s) was created before the failure and could not be deleted, unexpected error: %s", name, resourceID, describeError(err)),
+		)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// ModifyPlan validates the planned values and defaults the shares to the legacy share_group when they are not configured.
+func (r *PasswordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PasswordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changing the generation settings generates a new password
+	if !req.State.Raw.IsNull() && !plan.GenerateLength.IsNull() {
+		var generateLength types.Int64
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("generate_password_length"), &generateLength)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.GenerateLength.Equal(generateLength) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+		}
+	}
+
+	// Fail at plan time when folders or tags are used on a server without them
+	if !plan.Tags.IsNull() && !requirePlugin(r.data, pluginTags, "tags", &resp.Diagnostics) {
+		return
+	}
+	if !plan.FolderParent.IsNull() && !requirePlugin(r.data, pluginFolders, "folder_parent", &resp.Diagnostics) {
+		return
+	}
+	if configuredFolderID(plan) != "" && !requirePlugin(r.data, pluginFolders, "folder_parent_id", &resp.Diagnostics) {
+		return
+	}
+
+	// skip_validation lifts the URI format check for servers accepting other URIs, so it cannot be a schema validator.
+	// The plan is modified again with the provider configured before the resource is applied
+	if r.data != nil && !r.data.SkipValidation && !plan.URI.IsUnknown() && !uriPattern.MatchString(plan.URI.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("uri"),
+			"Validation Error",
+			"URI must be a valid HTTP or HTTPS URL",
+		)
+		return
+	}
+
+	// Show reviewers who gains or loses access when the shares of an existing resource change
+	if !req.State.Raw.IsNull() && r.client != nil {
+		var state PasswordResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.simulateShares(ctx, state, plan, &resp.Diagnostics)
+	}
+
+	if !plan.Shares.IsUnknown() || plan.ShareGroup.IsUnknown() {
+		return
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path.Root("shares"), legacyShares(plan.ShareGroup))
+	resp.Diagnostics.Append(diags...)
+}
+
+// plannedShareOperations resolves the planned shares into share operations.
+func (r *PasswordResource) plannedShareOperations(ctx context.Context, plan PasswordResourceModel, diags *diag.Diagnostics) []helper.ShareOperation {
+	var shares []PasswordShareModel
+	if plan.Shares.IsNull() || plan.Shares.IsUnknown() {
+		shares = legacyShares(plan.ShareGroup)
+	} else {
+		diags.Append(plan.Shares.ElementsAs(ctx, &shares, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	if len(shares) == 0 {
+		return nil
+	}
+
+	groupIDs, err := getGroupIDs(ctx, r.data.Lookups)
+	if err != nil {
+		diags.AddError("Cannot get groups", err.Error())
+		return nil
+	}
+
+	operations, err := resolveShares(shares, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return nil
+	}
+	return operations
+}
+
+// share applies share operations to a resource, waiting for the group permissions to be recorded when
+// wait_for_share_permissions is enabled.
+func (r *PasswordResource) share(ctx context.Context, plan PasswordResourceModel, resourceID string, operations []helper.ShareOperation) error {
+	err := shareResource(ctx, r.data, resourceID, operations)
+	if err != nil {
+		return err
+	}
+
+	if !plan.WaitForPermissions.ValueBool() {
+		return nil
+	}
+	return waitForSharePermissions(ctx, r.client, resourceID, operations)
+}
+
+// folderShareOperations returns the operations granting the permissions of the given folder that are not already
+// part of the given operations. The permission of the authenticated user is skipped, it owns the resources it creates.
+func (r *PasswordResource) folderShareOperations(ctx context.Context, folderID string, operations []helper.ShareOperation) ([]helper.ShareOperation, error) {
+	if folderID == "" {
+		return nil, nil
+	}
+
+	folder, err := r.client.GetFolder(ctx, folderID, &api.GetFolderOptions{ContainPermissions: true})
+	if err != nil {
+		return nil, err
+	}
+	return unmanagedShares(folder.Permissions, operations, r.client.GetUserID()), nil
+}
+
+// preservedShareOperations returns the permissions of the existing resource that are not managed by Terraform,
+// so they can be granted again when the resource has to be recreated.
+func (r *PasswordResource) preservedShareOperations(ctx context.Context, state PasswordResourceModel, planned []helper.ShareOperation, diags *diag.Diagnostics) []helper.ShareOperation {
+	var previous []PasswordShareModel
+	if !state.Shares.IsNull() && !state.Shares.IsUnknown() {
+		diags.Append(state.Shares.ElementsAs(ctx, &previous, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	groupIDs, err := getGroupIDs(ctx, r.data.Lookups)
+	if err != nil {
+		diags.AddError("Cannot get groups", err.Error())
+		return nil
+	}
+
+	// Groups that were deleted in the meantime are not managed anymore
+	var existing []PasswordShareModel
+	for _, share := range previous {
+		if _, ok := groupIDs[share.Group.ValueString()]; ok {
+			existing = append(existing, share)
+		}
+	}
+	previousOperations, err := resolveShares(existing, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return nil
+	}
+
+	permissions, err := r.client.GetResourcePermissions(ctx, state.ID.ValueString())
+	if err != nil {
+		diags.AddError("Cannot get resource permissions", err.Error())
+		return nil
+	}
+
+	return unmanagedShares(permissions, append(previousOperations, planned...), r.client.GetUserID())
+}
+
+// updateShares applies the changed shares to an existing resource.
+func (r *PasswordResource) updateShares(ctx context.Context, state, plan PasswordResourceModel, diags *diag.Diagnostics) {
+	var previous, planned []PasswordShareModel
+	if !state.Shares.IsNull() && !state.Shares.IsUnknown() {
+		diags.Append(state.Shares.ElementsAs(ctx, &previous, false)...)
+	}
+	diags.Append(plan.Shares.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	if r.data.RequireShared && len(planned) == 0 {
+		diags.AddError(
+			"Resource not shared",
+			fmt.Sprintf("Resource '%s' cannot be unshared from all groups because require_shared is enabled.", plan.Name.ValueString()),
+		)
+		return
+	}
+
+	groupIDs, err := getGroupIDs(ctx, r.data.Lookups)
+	if err != nil {
+		diags.AddError("Cannot get groups", err.Error())
+		return
+	}
+
+	plannedOperations, err := resolveShares(planned, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return
+	}
+
+	// Groups that were deleted in the meantime cannot be unshared anymore
+	var existing []PasswordShareModel
+	for _, share := range previous {
+		if _, ok := groupIDs[share.Group.ValueString()]; ok {
+			existing = append(existing, share)
+		}
+	}
+	previousOperations, err := resolveShares(existing, groupIDs)
+	if err != nil {
+		diags.AddError("Validation Error", err.Error())
+		return
+	}
+
+	permissions, err := r.client.GetResourcePermissions(ctx, state.ID.ValueString())
+	if err != nil {
+		diags.AddError("Cannot get resource permissions", err.Error())
+		return
+	}
+
+	changes := shareChanges(permissions, previousOperations, plannedOperations)
+	if len(changes) == 0 {
+		return
+	}
+
+	err = r.share(ctx, plan, state.ID.ValueString(), changes)
+	if err != nil {
+		diags.AddError("Cannot share resource", err.Error())
+		return
+	}
+}
+
+// simulateShares asks the server which users would gain or lose access with the planned shares and reports them as a warning.
+// The simulation is informative only, when it cannot run, such as for groups created in the same apply, it is skipped.
+func (r *PasswordResource) simulateShares(ctx context.Context, state, plan PasswordResourceModel, diags *diag.Diagnostics) {
+	if plan.Shares.Equal(state.Shares) && plan.ShareGroup.Equal(state.ShareGroup) || plan.ShareGroup.IsUnknown() {
+		return
+	}
+
+	var shares []PasswordShareModel
+	if !plan.Shares.IsUnknown() {
+		diags.Append(plan.Shares.ElementsAs(ctx, &shares, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+	for _, share := range shares {
+		if share.Group.IsUnknown() || share.Permission.IsUnknown() {
+			return
+		}
+	}
+
+	var skipped diag.Diagnostics
+	planned := r.plannedShareOperations(ctx, plan, &skipped)
+	if skipped.HasError() {
+		return
+	}
+
+	// The simulation is skipped when it cannot run, but the reviewers are told why there is no summary
+	skip := func(err error) {
+		diags.AddWarning(
+			"Cannot simulate share changes",
+			fmt.Sprintf("Could not simulate the share changes of password resource '%s': %s", state.Name.ValueString(), err.Error()),
+		)
+	}
+
+	groupIDs, err := getGroupIDs(ctx, r.data.Lookups)
+	if err != nil {
+		skip(err)
+		return
+	}
+	var previousShares []PasswordShareModel
+	if !state.Shares.IsNull() && !state.Shares.IsUnknown() {
+		diags.Append(state.Shares.ElementsAs(ctx, &previousShares, false)...)
+	}
+	var existing []PasswordShareModel
+	for _, share := range previousShares {
+		if _, ok := groupIDs[share.Group.ValueString()]; ok {
+			existing = append(existing, share)
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+	previous, err := resolveShares(existing, groupIDs)
+	if err != nil {
+		skip(err)
+		return
+	}
+
+	permissions, err := r.client.GetResourcePermissions(ctx, state.ID.ValueString())
+	if err != nil {
+		skip(err)
+		return
+	}
+
+	changes := shareChanges(permissions, previous, planned)
+	if len(changes) == 0 {
+		return
+	}
+
+	permissionChanges, err := helper.GeneratePermissionChanges(permissions, changes)
+	if err != nil {
+		skip(err)
+		return
+	}
+
+	result, err := r.client.SimulateShareResource(ctx, state.ID.ValueString(), api.ResourceShareRequest{Permissions: permissionChanges})
+	if err != nil {
+		skip(err)
+		return
+	}
+	if len(result.Changes.Added) == 0 && len(result.Changes.Removed) == 0 {
+		return
+	}
+
+	// Only the members of the changed groups can gain or lose access, users missing from them are shown by ID
+	usernames := make(map[string]string)
+	for _, change := range changes {
+		if change.ARO != "Group" {
+			continue
+		}
+
+		users, err := r.client.GetUsers(ctx, &api.GetUsersOptions{FilterHasGroup: []string{change.AROID}})
+		if err != nil {
+			diags.AddWarning(
+				"Cannot read group members",
+				fmt.Sprintf("Could not read the members of group %s, the users gaining or losing access to password resource '%s' through it are shown by ID: %s",
+					change.AROID, state.Name.ValueString(), err.Error()),
+			)
+			continue
+		}
+		for _, user := range users {
+			usernames[user.ID] = user.Username
+		}
+	}
+
+	var summary []string
+	if len(result.Changes.Added) > 0 {
+		summary = append(summary, "Users gaining access: "+simulatedUsers(result.Changes.Added, usernames))
+	}
+	if len(result.Changes.Removed) > 0 {
+		summary = append(summary, "Users losing access: "+simulatedUsers(result.Changes.Removed, usernames))
+	}
+	diags.AddWarning(
+		"Password resource access changes",
+		fmt.Sprintf("Applying the shares of password resource '%s' changes who can read it.\n\n%s",
+			state.Name.ValueString(), strings.Join(summary, "\n")),
+	)
+}
+
+// simulatedUsers returns the sorted usernames of the users in simulated share changes.
+func simulatedUsers(changes []api.ResourceShareSimulationChange, usernames map[string]string) string {
+	names := make([]string, 0, len(changes))
+	for _, change := range changes {
+		name, ok := usernames[change.User.ID]
+		if !ok {
+			name = change.User.ID
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// MoveState moves resources managed by other Passbolt providers into this provider.
+func (r *PasswordResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				moveRawState(ctx, req, resp, movedPasswordTypes, []movedAttribute{
+					{Target: "id", Sources: []string{"id"}},
+					{Target: "name", Sources: []string{"name"}},
+					{Target: "description", Sources: []string{"description"}},
+					{Target: "username", Sources: []string{"username"}},
+					{Target: "uri", Sources: []string{"uri", "url"}},
+					{Target: "password", Sources: []string{"password", "secret"}},
+					{Target: "folder_parent", Sources: []string{"folder_parent", "folder"}},
+					{Target: "share_group", Sources: []string{"share_group"}},
+				})
+				if resp.Diagnostics.HasError() || resp.TargetState.Raw.IsNull() {
+					return
+				}
+
+				// Moved states only know share_group, derive the shares from it
+				var shareGroup types.String
+				resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("share_group"), &shareGroup)...)
+				resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("shares"), legacyShares(shareGroup))...)
+			},
+		},
+	}
+}
+
+// passwordResourceModelV0 describes the resource data model before shares were added.
+type passwordResourceModelV0 struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Description  types.String   `tfsdk:"description"`
+	Username     types.String   `tfsdk:"username"`
+	URI          types.String   `tfsdk:"uri"`
+	Password     types.String   `tfsdk:"password"`
+	FolderParent types.String   `tfsdk:"folder_parent"`
+	ShareGroup   types.String   `tfsdk:"share_group"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// UpgradeState upgrades states written by previous versions of the resource.
+func (r *PasswordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 only had share_group, which becomes a share with the update permission
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":            schema.StringAttribute{Computed: true},
+					"name":          schema.StringAttribute{Required: true},
+					"description":   schema.StringAttribute{Optional: true},
+					"username":      schema.StringAttribute{Required: true},
+					"uri":           schema.StringAttribute{Required: true},
+					"password":      schema.StringAttribute{Required: true, Sensitive: true},
+					"folder_parent": schema.StringAttribute{Optional: true},
+					"share_group":   schema.StringAttribute{Optional: true},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior passwordResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				shares, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: passwordShareAttrTypes}, legacyShares(prior.ShareGroup))
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := PasswordResourceModel{
+					ID:            prior.ID,
+					Name:          prior.Name,
+					Description:   prior.Description,
+					Username:      prior.Username,
+					URI:           prior.URI,
+					Password:      prior.Password,
+					FolderParent:  prior.FolderParent,
+					ShareGroup:    prior.ShareGroup,
+					Shares:        shares,
+					Owners:        types.ListNull(types.StringType),
+					Tags:          types.SetNull(types.StringType),
+					PermissionIDs: types.MapNull(types.StringType),
+					Timeouts:      prior.Timeouts,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *PasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_password", &resp.Diagnostics) {
+		return
+	}
+
+	var state PasswordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Do not delete changes made in Passbolt since the last refresh
+	if r.data.DetectConcurrentChanges {
+		currentResource, err := r.client.GetResource(ctx, state.ID.ValueString())
+		if err != nil {
+			// Already deleted outside of Terraform
+			if isResourceNotFoundError(err) {
+				return
+			}
+
+			resp.Diagnostics.AddError(
+				"Error reading current resource",
+				"Could not read current resource, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		if !isUnchanged(state.Modified, currentResource) {
+			resp.Diagnostics.AddError(
+				"Resource modified outside of Terraform",
+				fmt.Sprintf("Resource '%s' (%s) was modified in Passbolt at %s since it was last read. "+
+					"Refresh the state and destroy again to confirm the deletion.",
+					state.Name.ValueString(), state.ID.ValueString(), modifiedValue(currentResource).ValueString()),
+			)
+			return
+		}
+	}
+
+	// Delete the resource, the status code of the response tells whether it was already deleted
+	_, err := doCustomRequest(ctx, r.client, "DELETE", "/resources/"+state.ID.ValueString()+".json", nil, nil)
+	if err != nil {
+		// Already deleted outside of Terraform
+		if isResourceNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting password",
+			"Could not delete password, unexpected error: "+describeError(err),
+		)
+		return
+	}
+}
+
+// favoriteID returns the ID of the favorite of the authenticated user on a resource, or an empty string.
+func favoriteID(view *passwordResourceView) string {
+	if view.Favorite == nil {
+		return ""
+	}
+	return view.Favorite.ID
+}
+
+// setFavorite marks or unmarks a resource as favorite of the authenticated user, favoriteID is its current favorite.
+func (r *PasswordResource) setFavorite(ctx context.Context, resourceID, favoriteID string, favorite bool, diags *diag.Diagnostics) {
+	switch {
+	case favorite && favoriteID == "":
+		_, err := r.client.CreateFavorite(ctx, resourceID)
+		if err != nil {
+			diags.AddError("Cannot mark resource as favorite", err.Error())
+		}
+	case !favorite && favoriteID != "":
+		err := r.client.DeleteFavorite(ctx, favoriteID)
+		if err != nil {
+			diags.AddError("Cannot unmark resource as favorite", err.Error())
+		}
+	}
+}
+
+// applyTags brings the tags of a resource in line with the planned tags, previous are the tags declared before.
+// Nothing is done when the tags are not managed.
+func (r *PasswordResource) applyTags(ctx context.Context, resourceID string, previous types.Set, plan PasswordResourceModel, diags *diag.Diagnostics) {
+	if plan.Tags.IsNull() || plan.Tags.IsUnknown() {
+		return
+	}
+
+	planned := setToStrings(ctx, plan.Tags, diags)
+	declared := setToStrings(ctx, previous, diags)
+	if diags.HasError() {
+		return
+	}
+
+	current, err := getResourceTags(ctx, r.client, resourceID)
+	if err != nil {
+		diags.AddError("Cannot get tags", err.Error())
+		return
+	}
+
+	// In additive mode the tags added outside of Terraform are kept, unless they were declared before
+	wanted := append([]string(nil), planned...)
+	if plan.AdditiveTags.ValueBool() {
+		for _, tag := range current {
+			if !containsString(planned, tag) && !containsString(declared, tag) {
+				wanted = append(wanted, tag)
+			}
+		}
+	}
+	sort.Strings(wanted)
+
+	if strings.Join(wanted, "\n") == strings.Join(current, "\n") {
+		return
+	}
+
+	err = setResourceTags(ctx, r.client, resourceID, wanted)
+	if err != nil {
+		diags.AddError("Cannot set tags", err.Error())
+		return
+	}
+}
+
+// createdResourceState returns the state of a resource that was just created from the plan, before it is shared,
+// marked as favorite or tagged.
+func createdResourceState(plan PasswordResourceModel, resourceID, folderID string) PasswordResourceModel {
+	partial := plan
+	partial.ID = types.StringValue(resourceID)
+	partial.FolderParentID = folderIDValue(folderID)
+	partial.Shares = types.SetValueMust(types.ObjectType{AttrTypes: passwordShareAttrTypes}, []attr.Value{})
+	partial.Favorite = types.BoolValue(false)
+	if !plan.Tags.IsNull() {
+		partial.Tags = types.SetValueMust(types.StringType, []attr.Value{})
+	}
+	partial.Modified = types.StringNull()
+	partial.Personal = types.BoolNull()
+	partial.Owners = types.ListNull(types.StringType)
+	partial.PermissionIDs = types.MapNull(types.StringType)
+	return partial
+}
+
+// configuredFolderID returns the parent folder ID set in the configuration, or an empty string when it is computed.
+func configuredFolderID(plan PasswordResourceModel) string {
+	if plan.FolderParentID.IsUnknown() {
+		return ""
+	}
+	return plan.FolderParentID.ValueString()
+}
+
+// folderIDValue returns the parent folder ID, or null for resources at the root.
+func folderIDValue(folderID string) types.String {
+	if folderID == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(folderID)
+}
+
+// passwordResourceView is a password resource as returned by the resource view, along with everything its state is read from.
+type passwordResourceView struct {
+	resourceWithPermissions
+	Folder *api.Folder `json:"folder,omitempty"`
+}
+
+// getPasswordResourceOptions are the query parameters of the resource view used to read a password resource.
+type getPasswordResourceOptions struct {
+	getResourcesOptions
+	ContainFavorite bool `url:"contain[favorite],omitempty"`
+	ContainTag      bool `url:"contain[tag],omitempty"`
+	ContainFolder   bool `url:"contain[folder],omitempty"`
+}
+
+// getResourceView reads a resource with its permissions, favorite, tags and parent folder in a single request.
+func (r *PasswordResource) getResourceView(ctx context.Context, resourceID string) (*passwordResourceView, error) {
+	msg, err := doCustomRequest(ctx, r.client, "GET", "/resources/"+resourceID+".json", nil, getPasswordResourceOptions{
+		getResourcesOptions: getResourcesOptions{
+			ContainPermissions:            true,
+			ContainPermissionsUserProfile: true,
+			ContainPermissionsGroup:       true,
+		},
+		ContainFavorite: true,
+		ContainTag:      r.data.Capabilities.HasPlugin(pluginTags),
+		ContainFolder:   r.data.Capabilities.HasPlugin(pluginFolders),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var view passwordResourceView
+	err = json.Unmarshal(msg.Body, &view)
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// setComputedAttributes reads a resource once to set its modification time and its permission attributes,
+// or null values if it cannot be read.
+func (r *PasswordResource) setComputedAttributes(ctx context.Context, resourceID string, model *PasswordResourceModel) {
+	view, err := r.getResourceView(ctx, resourceID)
+	if err != nil {
+		model.Modified = types.StringNull()
+		model.Personal = types.BoolNull()
+		model.Owners = types.ListNull(types.StringType)
+		model.PermissionIDs = types.MapNull(types.StringType)
+		return
+	}
+
+	model.Modified = modifiedValue(&view.Resource)
+	setPermissionAttributes(view.Permissions, model)
+}
+
+// setPermissionAttributes sets whether a resource is personal, who owns it and the IDs of its group permissions.
+func setPermissionAttributes(permissions []permissionWithARO, model *PasswordResourceModel) {
+	personal, owners := resourceOwnership(permissions)
+	ownerValues := make([]attr.Value, 0, len(owners))
+	for _, owner := range owners {
+		ownerValues = append(ownerValues, types.StringValue(owner))
+	}
+
+	permissionIDs := make(map[string]attr.Value)
+	for _, permission := range permissions {
+		if permission.Group != nil {
+			permissionIDs[permission.Group.Name] = types.StringValue(permission.ID)
+		}
+	}
+
+	model.Personal = types.BoolValue(personal)
+	model.Owners = types.ListValueMust(types.StringType, ownerValues)
+	model.PermissionIDs = types.MapValueMust(types.StringType, permissionIDs)
+}
+
+// apiPermissions returns the permissions without the users and groups they grant access to.
+func apiPermissions(permissions []permissionWithARO) []api.Permission {
+	result := make([]api.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		result = append(result, permission.Permission)
+	}
+	return result
+}
+
+// waitForCreatedResource retries reading a resource that was just created while the server reports it as not found,
+// which happens for a short while on servers caching the resources index.
+func waitForCreatedResource(ctx context.Context, client *api.Client, resourceID string) error {
+	delay := createdResourceDelay
+	for attempt := 1; ; attempt++ {
+		_, err := client.GetResource(ctx, resourceID)
+		if err == nil || !isResourceNotFoundError(err) || attempt == createdResourceAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// modifiedValue returns the modification time of a resource in RFC 3339 format.
+func modifiedValue(resource *api.Resource) types.String {
+	if resource.Modified == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(resource.Modified.UTC().Format(time.RFC3339))
+}
+
+// isUnchanged checks if a resource was not modified since the recorded modification time.
+// States written before the modification time was recorded are considered unchanged.
+func isUnchanged(recorded types.String, resource *api.Resource) bool {
+	if recorded.IsNull() || recorded.IsUnknown() {
+		return true
+	}
+	return recorded.Equal(modifiedValue(resource))
+}
+
+// generatePassword returns a random password of the given length using every character class at least once.
+func generatePassword(length int) (string, error) {
+	policy := passwordPolicy{
+		MinLength:        length,
+		RequireLowercase: true,
+		RequireUppercase: true,
+		RequireDigits:    true,
+		RequireSymbols:   true,
+	}
+
+	alphabetSize := big.NewInt(int64(len(generatedPasswordAlphabet)))
+	password := make([]byte, length)
+	for {
+		for i := range password {
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return "", fmt.Errorf("reading random bytes: %w", err)
+			}
+			password[i] = generatedPasswordAlphabet[n.Int64()]
+		}
+
+		// Passwords missing a character class are rare at the minimum length, draw again
+		if policy.allows(string(password)) {
+			return string(password), nil
+		}
+	}
+}
+
+// normalize returns the value to send to Passbolt, normalized when normalize_values is enabled.
+func (r *PasswordResource) normalize(value string, normalizer func(string) string) string {
+	if !r.data.NormalizeValues {
+		return value
+	}
+	return normalizer(value)
+}
+
+// refreshed returns the value read from Passbolt, or the recorded value when it only differs by normalization.
+func (r *PasswordResource) refreshed(recorded types.String, remote string, normalizer func(string) string) types.String {
+	if r.data.NormalizeValues && !recorded.IsNull() && !recorded.IsUnknown() && normalizer(recorded.ValueString()) == normalizer(remote) {
+		return recorded
+	}
+	return types.StringValue(remote)
+}
+
+// normalizeUsername removes the whitespace around a username.
+func normalizeUsername(username string) string {
+	return strings.TrimSpace(username)
+}
+
+// normalizeURI removes the whitespace around a URI and lowercases its scheme and host, which are case insensitive.
+// The path, query and fragment are kept as they are.
+func normalizeURI(uri string) string {
+	uri = strings.TrimSpace(uri)
+
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return uri
+	}
+
+	hostEnd := strings.IndexAny(rest, "/?#")
+	if hostEnd < 0 {
+		hostEnd = len(rest)
+	}
+
+	// Credentials in the URI are case sensitive
+	hostStart := strings.LastIndex(rest[:hostEnd], "@") + 1
+	return strings.ToLower(scheme) + "://" + rest[:hostStart] + strings.ToLower(rest[hostStart:hostEnd]) + rest[hostEnd:]
 }
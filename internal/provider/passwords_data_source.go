@@ -2,9 +2,17 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/passbolt/go-passbolt/api"
 )
@@ -23,21 +31,67 @@ func NewPasswordsDataSource() datasource.DataSource {
 // PasswordsDataSource is the data source implementation.
 type PasswordsDataSource struct {
 	client *api.Client
+	data   *PassboltProviderData
 }
 
 // PasswordsDataSourceModel describes the data source data model.
 type PasswordsDataSourceModel struct {
-	Passwords []PasswordModel `tfsdk:"passwords"`
+	ID                 types.String             `tfsdk:"id"`
+	IncludePermissions types.Bool               `tfsdk:"include_permissions"`
+	ModifiedSince      types.String             `tfsdk:"modified_since"`
+	OrphansOnly        types.Bool               `tfsdk:"orphans_only"`
+	IncludeSecretsFor  []types.String           `tfsdk:"include_secrets_for"`
+	Passwords          []PasswordModel          `tfsdk:"passwords"`
+	PasswordsByID      map[string]PasswordModel `tfsdk:"passwords_by_id"`
+	PasswordsByName    map[string]PasswordModel `tfsdk:"passwords_by_name"`
 }
 
 // PasswordModel describes a single password resource.
 type PasswordModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Username     types.String `tfsdk:"username"`
-	URI          types.String `tfsdk:"uri"`
-	FolderParent types.String `tfsdk:"folder_parent"`
+	ID               types.String              `tfsdk:"id"`
+	Name             types.String              `tfsdk:"name"`
+	Description      types.String              `tfsdk:"description"`
+	Username         types.String              `tfsdk:"username"`
+	URI              types.String              `tfsdk:"uri"`
+	FolderParent     types.String              `tfsdk:"folder_parent"`
+	Modified         types.String              `tfsdk:"modified"`
+	Password         types.String              `tfsdk:"password"`
+	Permissions      []PasswordPermissionModel `tfsdk:"permissions"`
+	SharedWithGroups []types.String            `tfsdk:"shared_with_groups"`
+	Personal         types.Bool                `tfsdk:"personal"`
+	Owners           []types.String            `tfsdk:"owners"`
+}
+
+// PasswordPermissionModel describes a single permission on a password resource.
+type PasswordPermissionModel struct {
+	ARO   types.String `tfsdk:"aro"`
+	AROID types.String `tfsdk:"aro_id"`
+	Name  types.String `tfsdk:"name"`
+	Type  types.Int64  `tfsdk:"type"`
+}
+
+// resourceWithPermissions is a resource as returned by the index when all permissions are contained.
+type resourceWithPermissions struct {
+	api.Resource
+	Permissions []permissionWithARO `json:"permissions,omitempty"`
+}
+
+// permissionWithARO is a permission including the user or group it grants access to.
+type permissionWithARO struct {
+	api.Permission
+	User  *api.User  `json:"user,omitempty"`
+	Group *api.Group `json:"group,omitempty"`
+}
+
+// getResourcesOptions are the query parameters of the resources index not covered by api.GetResourcesOptions.
+type getResourcesOptions struct {
+	ContainPermissions            bool `url:"contain[permissions],omitempty"`
+	ContainPermissionsUserProfile bool `url:"contain[permissions.user.profile],omitempty"`
+	ContainPermissionsGroup       bool `url:"contain[permissions.group],omitempty"`
+
+	FilterModifiedAfter string `url:"filter[modified-after],omitempty"`
+	// An empty parent folder ID selects the resources at the root
+	FilterHasParent []string `url:"filter[has-parent][],omitempty"`
 }
 
 // Configure adds the provider configured client to the data source.
@@ -46,16 +100,17 @@ func (d *PasswordsDataSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*PassboltProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			"Expected *api.Client, got: %T. Please report this issue to the provider developers.",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
+	d.data = data
 }
 
 // Metadata returns the data source type name.
@@ -67,34 +122,117 @@ func (d *PasswordsDataSource) Metadata(_ context.Context, req datasource.Metadat
 func (d *PasswordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A hash of the identifiers of the returned password resources, it only changes when the set of resources changes",
+			},
+			"include_permissions": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return the permissions of each password resource",
+			},
+			"modified_since": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the password resources modified after this time, in RFC 3339 format such as 2024-01-02T15:04:05Z",
+			},
+			"include_secrets_for": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The IDs or names of the password resources to decrypt the password of, the other resources only return their metadata",
+			},
+			"orphans_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return the password resources that are not in any folder",
+			},
 			"passwords": schema.ListNestedAttribute{
+				Computed:     true,
+				Description:  "List of password resources, sorted by name then id",
+				NestedObject: passwordNestedObject(),
+			},
+			"passwords_by_id": schema.MapNestedAttribute{
+				Computed:     true,
+				Description:  "The password resources keyed by id",
+				NestedObject: passwordNestedObject(),
+			},
+			"passwords_by_name": schema.MapNestedAttribute{
+				Computed:     true,
+				Description:  "The password resources keyed by name, when several resources have the same name the one with the lowest id is used",
+				NestedObject: passwordNestedObject(),
+			},
+		},
+	}
+}
+
+// passwordNestedObject describes a single password resource in the results of the data source.
+func passwordNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the password resource",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the password resource",
+			},
+			"description": schema.StringAttribute{
 				Computed:    true,
-				Description: "List of password resources",
+				Description: "The description of the password resource",
+			},
+			"username": schema.StringAttribute{
+				Computed:    true,
+				Description: "The username for the password resource",
+			},
+			"uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URI for the password resource",
+			},
+			"folder_parent": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the parent folder",
+			},
+			"modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the password resource was last modified",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The decrypted password, only set for the resources listed in include_secrets_for. Null for resources only holding a TOTP secret",
+			},
+			"shared_with_groups": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the groups the password resource is shared with, sorted by name",
+			},
+			"personal": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the password resource is only accessible to a single user",
+			},
+			"owners": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The usernames and group names with the owner permission on the password resource, sorted",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The permissions of the password resource sorted by aro then name, only set when include_permissions is enabled",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
+						"aro": schema.StringAttribute{
 							Computed:    true,
-							Description: "The unique identifier of the password resource",
+							Description: "The type of the access request object, either User or Group",
 						},
-						"name": schema.StringAttribute{
-							Computed:    true,
-							Description: "The name of the password resource",
-						},
-						"description": schema.StringAttribute{
-							Computed:    true,
-							Description: "The description of the password resource",
-						},
-						"username": schema.StringAttribute{
+						"aro_id": schema.StringAttribute{
 							Computed:    true,
-							Description: "The username for the password resource",
+							Description: "The unique identifier of the user or group",
 						},
-						"uri": schema.StringAttribute{
+						"name": schema.StringAttribute{
 							Computed:    true,
-							Description: "The URI for the password resource",
+							Description: "The username of the user or the name of the group",
 						},
-						"folder_parent": schema.StringAttribute{
+						"type": schema.Int64Attribute{
 							Computed:    true,
-							Description: "The name of the parent folder",
+							Description: "The permission type: 1 = read, 7 = update, 15 = owner",
 						},
 					},
 				},
@@ -105,43 +243,133 @@ func (d *PasswordsDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 
 // Read refreshes the Terraform state with the latest data.
 func (d *PasswordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state PasswordsDataSourceModel
+	if !requireConfigured(d.data, "passbolt_passwords", &resp.Diagnostics) {
+		return
+	}
 
-	// Get all resources from Passbolt
-	resources, err := d.client.GetResources(ctx, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading passwords",
-			"Could not read passwords, unexpected error: "+err.Error(),
-		)
+	var state PasswordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get all folders for parent folder mapping
-	folders, err := d.client.GetFolders(ctx, nil)
+	includePermissions := state.IncludePermissions.ValueBool()
+
+	var modifiedSince time.Time
+	if !state.ModifiedSince.IsNull() {
+		var err error
+		modifiedSince, err = time.Parse(time.RFC3339, state.ModifiedSince.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("modified_since"),
+				"Invalid modified_since",
+				fmt.Sprintf("modified_since must be a time in RFC 3339 format: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	// Get all resources from Passbolt, users and groups are always needed for shared_with_groups and owners
+	opts := getResourcesOptions{
+		ContainPermissions:            true,
+		ContainPermissionsUserProfile: true,
+		ContainPermissionsGroup:       true,
+	}
+
+	// Let the server filter the resources, so large vaults do not return every resource
+	filtered := false
+	if !modifiedSince.IsZero() {
+		opts.FilterModifiedAfter = modifiedSince.UTC().Format(time.RFC3339)
+		filtered = true
+	}
+	if state.OrphansOnly.ValueBool() && d.data.Capabilities.HasPlugin(pluginFolders) {
+		opts.FilterHasParent = []string{""}
+		filtered = true
+	}
+
+	resources, err := d.getResources(ctx, opts)
+	if err != nil && filtered && classifyError(err) == errorValidation {
+		// Ask servers rejecting the filters for every resource, the filters are applied below
+		opts.FilterModifiedAfter = ""
+		opts.FilterHasParent = nil
+		resources, err = d.getResources(ctx, opts)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading folders",
-			"Could not read folders, unexpected error: "+err.Error(),
+			"Error reading passwords",
+			"Could not read passwords, unexpected error: "+describeError(err),
 		)
 		return
 	}
 
-	// Create a map of folder IDs to names
+	// Create a map of folder IDs to names, servers without the folders plugin have no folders
 	folderMap := make(map[string]string)
-	for _, folder := range folders {
-		folderMap[folder.ID] = folder.Name
+	if d.data.Capabilities.HasPlugin(pluginFolders) {
+		folders, err := d.data.Lookups.Folders(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading folders",
+				"Could not read folders, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		for _, folder := range folders {
+			folderMap[folder.ID] = folder.Name
+		}
 	}
 
+	secretsFor := make(map[string]bool, len(state.IncludeSecretsFor))
+	for _, key := range state.IncludeSecretsFor {
+		secretsFor[key.ValueString()] = true
+	}
+	matched := make(map[string]bool, len(state.IncludeSecretsFor))
+	resourceTypes := make(map[string]string)
+
 	// Convert resources to our model
 	passwords := make([]PasswordModel, 0, len(resources))
 	for _, resource := range resources {
+		// Servers without the filters ignore them, so they are applied again
+		if !modifiedSince.IsZero() && (resource.Modified == nil || !resource.Modified.After(modifiedSince)) {
+			continue
+		}
+
+		// Resources not in any folder sit in the root of the user's workspace
+		if state.OrphansOnly.ValueBool() && resource.FolderParentID != "" {
+			continue
+		}
+
 		password := PasswordModel{
 			ID:          types.StringValue(resource.ID),
 			Name:        types.StringValue(resource.Name),
 			Description: types.StringValue(resource.Description),
 			Username:    types.StringValue(resource.Username),
 			URI:         types.StringValue(resource.URI),
+			Modified:    modifiedValue(&resource.Resource),
+			Password:    types.StringNull(),
+		}
+
+		// Only decrypt the secrets that were asked for, decryption is the expensive part
+		if secretsFor[resource.ID] || secretsFor[resource.Name] {
+			matched[resource.ID] = true
+			matched[resource.Name] = true
+
+			password.Password, err = d.decryptPassword(ctx, resource.Resource, resourceTypes)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading password",
+					fmt.Sprintf("Could not decrypt the password of resource '%s' (%s), unexpected error: %s", resource.Name, resource.ID, describeError(err)),
+				)
+				return
+			}
+			if password.Password.IsNull() {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("include_secrets_for"),
+					"Password resource without password",
+					fmt.Sprintf("Resource '%s' (%s) only holds a TOTP secret, its password is null", resource.Name, resource.ID),
+				)
+			}
 		}
 
 		// Set folder parent if available
@@ -151,15 +379,191 @@ func (d *PasswordsDataSource) Read(ctx context.Context, req datasource.ReadReque
 			}
 		}
 
+		// Set the groups the resource is shared with
+		groupNames := make([]string, 0)
+		for _, permission := range resource.Permissions {
+			if permission.Group != nil {
+				groupNames = append(groupNames, permission.Group.Name)
+			}
+		}
+		sort.Strings(groupNames)
+		password.SharedWithGroups = make([]types.String, 0, len(groupNames))
+		for _, groupName := range groupNames {
+			password.SharedWithGroups = append(password.SharedWithGroups, types.StringValue(groupName))
+		}
+
+		personal, owners := resourceOwnership(resource.Permissions)
+		password.Personal = types.BoolValue(personal)
+		password.Owners = make([]types.String, 0, len(owners))
+		for _, owner := range owners {
+			password.Owners = append(password.Owners, types.StringValue(owner))
+		}
+
+		// Set permissions if requested
+		if includePermissions {
+			password.Permissions = make([]PasswordPermissionModel, 0, len(resource.Permissions))
+			for _, permission := range resource.Permissions {
+				password.Permissions = append(password.Permissions, PasswordPermissionModel{
+					ARO:   types.StringValue(permission.ARO),
+					AROID: types.StringValue(permission.AROForeignKey),
+					Name:  types.StringValue(permission.aroName()),
+					Type:  types.Int64Value(int64(permission.Type)),
+				})
+			}
+			sort.SliceStable(password.Permissions, func(i, j int) bool {
+				a, b := password.Permissions[i], password.Permissions[j]
+				if a.ARO.ValueString() != b.ARO.ValueString() {
+					return a.ARO.ValueString() < b.ARO.ValueString()
+				}
+				if a.Name.ValueString() != b.Name.ValueString() {
+					return a.Name.ValueString() < b.Name.ValueString()
+				}
+				return a.AROID.ValueString() < b.AROID.ValueString()
+			})
+		}
+
 		passwords = append(passwords, password)
 	}
 
+	// The server does not guarantee any order, sort to keep the result stable between runs
+	sort.SliceStable(passwords, func(i, j int) bool {
+		if passwords[i].Name.ValueString() != passwords[j].Name.ValueString() {
+			return passwords[i].Name.ValueString() < passwords[j].Name.ValueString()
+		}
+		return passwords[i].ID.ValueString() < passwords[j].ID.ValueString()
+	})
+
+	// A misspelled name or an ID of a resource filtered out would otherwise silently return no password
+	var unmatched []string
+	for _, key := range state.IncludeSecretsFor {
+		if !matched[key.ValueString()] {
+			unmatched = append(unmatched, key.ValueString())
+		}
+	}
+	if len(unmatched) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("include_secrets_for"),
+			"Passwords not found",
+			fmt.Sprintf("No returned password resource has the ID or name '%s', their passwords were not decrypted", strings.Join(unmatched, "', '")),
+		)
+	}
+
+	state.ID = types.StringValue(passwordsID(passwords))
 	state.Passwords = passwords
 
+	// Passwords are sorted by name then id, so the first password of each name is kept
+	state.PasswordsByID = make(map[string]PasswordModel, len(passwords))
+	state.PasswordsByName = make(map[string]PasswordModel, len(passwords))
+	for _, password := range passwords {
+		state.PasswordsByID[password.ID.ValueString()] = password
+		if _, exists := state.PasswordsByName[password.Name.ValueString()]; !exists {
+			state.PasswordsByName[password.Name.ValueString()] = password
+		}
+	}
+
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// getResources gets all resources using the given contain options.
+func (d *PasswordsDataSource) getResources(ctx context.Context, opts getResourcesOptions) ([]resourceWithPermissions, error) {
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/resources.json", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []resourceWithPermissions
+	err = json.Unmarshal(msg.Body, &resources)
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// decryptPassword decrypts the password of a resource with the unlocked key shared by the whole run, the password is null
+// for resources without one. The slugs of the resource types are memoized in resourceTypes, keyed by resource type ID.
+func (d *PasswordsDataSource) decryptPassword(ctx context.Context, resource api.Resource, resourceTypes map[string]string) (types.String, error) {
+	slug, ok := resourceTypes[resource.ResourceTypeID]
+	if !ok {
+		resourceType, err := d.client.GetResourceType(ctx, resource.ResourceTypeID)
+		if err != nil {
+			return types.StringNull(), fmt.Errorf("getting resource type: %w", err)
+		}
+		slug = resourceType.Slug
+		resourceTypes[resource.ResourceTypeID] = slug
+	}
+
+	// TOTP resources hold no password, their secret is not fetched
+	if slug == "totp" {
+		return types.StringNull(), nil
+	}
+
+	secret, err := d.client.GetSecret(ctx, resource.ID)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("getting resource secret: %w", err)
+	}
+
+	data, err := d.data.Key.Decrypt(secret.Data)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("decrypting resource secret: %w", err)
+	}
+
+	// Only the password-string type stores the bare password, the others store a JSON object
+	switch slug {
+	case cleartextDescriptionType:
+		return types.StringValue(data), nil
+	default:
+		var secretData api.SecretDataTypePasswordAndDescription
+		err = json.Unmarshal([]byte(data), &secretData)
+		if err != nil {
+			return types.StringNull(), fmt.Errorf("parsing resource secret: %w", err)
+		}
+		return types.StringValue(secretData.Password), nil
+	}
+}
+
+// passwordsID returns an identifier derived from the IDs of the given password resources.
+func passwordsID(passwords []PasswordModel) string {
+	ids := make([]string, 0, len(passwords))
+	for _, password := range passwords {
+		ids = append(ids, password.ID.ValueString())
+	}
+	sort.Strings(ids)
+
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// aroName returns the username or group name the permission grants access to.
+func (p permissionWithARO) aroName() string {
+	switch {
+	case p.User != nil:
+		return p.User.Username
+	case p.Group != nil:
+		return p.Group.Name
+	default:
+		return ""
+	}
+}
+
+// resourceOwnership tells whether a resource is personal, that is only accessible to the user it belongs to, and
+// returns the sorted names of the users and groups owning it.
+func resourceOwnership(permissions []permissionWithARO) (bool, []string) {
+	owners := make([]string, 0)
+	for _, permission := range permissions {
+		if permission.Type == permissionTypes["owner"] {
+			owners = append(owners, permission.aroName())
+		}
+	}
+	sort.Strings(owners)
+
+	personal := len(permissions) == 1 && permissions[0].ARO == "User"
+	return personal, owners
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PendingUsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &PendingUsersDataSource{}
+)
+
+// NewPendingUsersDataSource is a helper function to simplify the provider implementation.
+func NewPendingUsersDataSource() datasource.DataSource {
+	return &PendingUsersDataSource{}
+}
+
+// PendingUsersDataSource is the data source implementation.
+type PendingUsersDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// PendingUsersDataSourceModel describes the data source data model.
+type PendingUsersDataSourceModel struct {
+	OlderThanDays types.Int64        `tfsdk:"older_than_days"`
+	Users         []PendingUserModel `tfsdk:"users"`
+}
+
+// PendingUserModel describes a single user who did not complete the account setup.
+type PendingUserModel struct {
+	ID         types.String `tfsdk:"id"`
+	Username   types.String `tfsdk:"username"`
+	FirstName  types.String `tfsdk:"first_name"`
+	LastName   types.String `tfsdk:"last_name"`
+	Invited    types.String `tfsdk:"invited"`
+	InviteDays types.Int64  `tfsdk:"invite_days"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PendingUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *PendingUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pending_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *PendingUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"older_than_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return the users invited at least this number of days ago (default 0, all pending users)",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of invited users who did not complete the account setup, oldest invitation first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the user",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username (email) of the user",
+						},
+						"first_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The first name of the user",
+						},
+						"last_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The last name of the user",
+						},
+						"invited": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the user was invited",
+						},
+						"invite_days": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of full days since the user was invited",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PendingUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_pending_users", &resp.Diagnostics) {
+		return
+	}
+
+	var state PendingUsersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OlderThanDays.ValueInt64() < 0 {
+		resp.Diagnostics.AddError("Validation Error", "older_than_days cannot be negative")
+		return
+	}
+
+	// Get all users from Passbolt, the disabled date is needed to leave disabled users out
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/users.json", nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading users",
+			"Could not read users, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var users []userWithDisabled
+	err = json.Unmarshal(msg.Body, &users)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading users",
+			"Could not decode users, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	now := time.Now()
+	pending := make([]userWithDisabled, 0)
+	for _, user := range users {
+		if user.Active || user.Disabled != nil || user.Created == nil {
+			continue
+		}
+		if inviteDays(user.Created.Time, now) < state.OlderThanDays.ValueInt64() {
+			continue
+		}
+		pending = append(pending, user)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if !pending[i].Created.Equal(pending[j].Created.Time) {
+			return pending[i].Created.Before(pending[j].Created.Time)
+		}
+		return pending[i].ID < pending[j].ID
+	})
+
+	state.Users = make([]PendingUserModel, 0, len(pending))
+	for _, user := range pending {
+		model := PendingUserModel{
+			ID:         types.StringValue(user.ID),
+			Username:   types.StringValue(user.Username),
+			FirstName:  types.StringNull(),
+			LastName:   types.StringNull(),
+			Invited:    types.StringValue(user.Created.UTC().Format(time.RFC3339)),
+			InviteDays: types.Int64Value(inviteDays(user.Created.Time, now)),
+		}
+
+		if user.Profile != nil {
+			model.FirstName = types.StringValue(user.Profile.FirstName)
+			model.LastName = types.StringValue(user.Profile.LastName)
+		}
+
+		state.Users = append(state.Users, model)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// inviteDays returns the number of full days between an invitation and now.
+func inviteDays(invited, now time.Time) int64 {
+	return int64(now.Sub(invited) / (24 * time.Hour))
+}
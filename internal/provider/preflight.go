@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// preflightAdmin is the preflight check requiring the authenticated user to be an administrator,
+// as managing users and groups needs.
+const preflightAdmin = "admin"
+
+// preflightPlugins are the preflight checks requiring a server plugin, keyed by their name in the configuration.
+var preflightPlugins = map[string]string{
+	"folders":         pluginFolders,
+	"tags":            pluginTags,
+	"totp":            pluginTOTP,
+	"password_expiry": pluginPasswordExpiry,
+	"metadata":        pluginMetadata,
+}
+
+// preflightCheckNames returns the names of all preflight checks, sorted.
+func preflightCheckNames() []string {
+	names := []string{preflightAdmin}
+	for name := range preflightPlugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runPreflightChecks verifies the given checks and returns a description of every failed one,
+// so that all missing capabilities are reported at once.
+func runPreflightChecks(ctx context.Context, client *api.Client, capabilities *ServerCapabilities, checks []string) []string {
+	var failures []string
+	for _, check := range checks {
+		if check == preflightAdmin {
+			me, err := client.GetMe(ctx)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("admin: cannot read the authenticated user: %s", err.Error()))
+			} else if me.Role == nil || me.Role.Name != "admin" {
+				failures = append(failures, fmt.Sprintf("admin: %s is not an administrator, users and groups cannot be managed", me.Username))
+			}
+			continue
+		}
+
+		plugin := preflightPlugins[check]
+		if capabilities == nil {
+			failures = append(failures, fmt.Sprintf("%s: the server capabilities could not be detected", check))
+			continue
+		}
+		if !capabilities.HasPlugin(plugin) {
+			failures = append(failures, fmt.Sprintf("%s: the %s plugin is disabled on %s", check, plugin, serverName(capabilities)))
+		}
+	}
+	return failures
+}
+
+// requireAdmin adds an error if the authenticated user is not an administrator, as resources changing users, groups or
+// server settings and admin-only data sources need, so that the plan fails up front instead of the apply failing on a
+// forbidden API call.
+// A role that cannot be read is assumed to be sufficient, the API then reports the actual failure.
+func requireAdmin(ctx context.Context, data *PassboltProviderData, typeName string, diags *diag.Diagnostics) bool {
+	if data == nil || data.Lookups == nil {
+		return true
+	}
+
+	me, err := data.Lookups.Me(ctx)
+	if err != nil || me.Role == nil || me.Role.Name == "admin" {
+		return true
+	}
+
+	diags.AddError(
+		"Service account lacks admin role",
+		fmt.Sprintf("%s requires an administrator account, but the provider is authenticated as %s. "+
+			"Configure the provider with an administrator account or remove %s from the configuration.",
+			typeName, me.Username, typeName),
+	)
+	return false
+}
+
+// plansChange reports whether a plan creates, updates or destroys the resource, as opposed to leaving it unchanged.
+func plansChange(req resource.ModifyPlanRequest) bool {
+	return req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw)
+}
@@ -3,20 +3,53 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/passbolt/go-passbolt/api"
 )
 
+// Default timeouts for resource operations when no timeouts block is configured.
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// isCancelled checks if the operation was cancelled or timed out, adding an error if so.
+// It is used before starting steps that cannot be interrupted halfway, such as recreating a resource.
+func isCancelled(ctx context.Context, diags *diag.Diagnostics) bool {
+	err := ctx.Err()
+	if err == nil {
+		return false
+	}
+
+	diags.AddError(
+		"Operation cancelled",
+		"The operation was stopped before making further changes: "+err.Error(),
+	)
+	return true
+}
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &PassboltProvider{}
+	_ provider.Provider              = &PassboltProvider{}
+	_ provider.ProviderWithFunctions = &PassboltProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -35,9 +68,65 @@ type PassboltProvider struct {
 
 // PassboltProviderModel describes the provider data model.
 type PassboltProviderModel struct {
-	BaseURL    types.String `tfsdk:"base_url"`
-	PrivateKey types.String `tfsdk:"private_key"`
-	Passphrase types.String `tfsdk:"passphrase"`
+	BaseURL       types.String `tfsdk:"base_url"`
+	PrivateKey    types.String `tfsdk:"private_key"`
+	Passphrase    types.String `tfsdk:"passphrase"`
+	RequireShared types.Bool   `tfsdk:"require_shared"`
+
+	DetectConcurrentChanges types.Bool `tfsdk:"detect_concurrent_changes"`
+	WarnOnDuplicates        types.Bool `tfsdk:"warn_on_duplicates"`
+	RollbackFailedCreates   types.Bool `tfsdk:"rollback_failed_creates"`
+	SkipValidation          types.Bool `tfsdk:"skip_validation"`
+	NormalizeValues         types.Bool `tfsdk:"normalize_values"`
+	VerifyServer            types.Bool `tfsdk:"verify_server"`
+	ReadOnly                types.Bool `tfsdk:"read_only"`
+	PreflightChecks         types.List `tfsdk:"preflight_checks"`
+
+	MaxRetries           types.Int64  `tfsdk:"max_retries"`
+	MaxElapsedTime       types.String `tfsdk:"max_elapsed_time"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+
+	LoginMaxAttempts types.Int64  `tfsdk:"login_max_attempts"`
+	LoginRetryDelay  types.String `tfsdk:"login_retry_delay"`
+
+	DebugHTTPTraceFile types.String `tfsdk:"debug_http_trace_file"`
+}
+
+// PassboltProviderData is the data made available to data sources and resources.
+// It is shared by all operations Terraform runs concurrently and is not modified after Configure,
+// the only mutable state is in Lookups, which guards it with its own lock, in Key, which is unlocked once and
+// cleared when the provider stops, and the session of the client, which is renewed under loginMu.
+type PassboltProviderData struct {
+	Client                  *api.Client
+	RequireShared           bool
+	DetectConcurrentChanges bool
+	WarnOnDuplicates        bool
+	RollbackFailedCreates   bool
+	SkipValidation          bool
+	NormalizeValues         bool
+	ReadOnly                bool
+	Retry                   RetryConfig
+	LoginRetry              LoginRetryConfig
+	Capabilities            *ServerCapabilities
+	Lookups                 *Lookups
+	Key                     *UnlockedKey
+
+	loginMu sync.Mutex
+}
+
+// requireConfigured reports a specific error when the provider data is missing, instead of failing on a nil client.
+// This happens when the provider could not be configured, such as with a partial configuration in tests.
+func requireConfigured(data *PassboltProviderData, typeName string, diags *diag.Diagnostics) bool {
+	if data != nil && data.Client != nil {
+		return true
+	}
+
+	diags.AddError(
+		"Provider not configured",
+		fmt.Sprintf("%s cannot be used before the passbolt provider is configured. "+
+			"Fix the errors reported for the provider configuration, or make sure base_url, private_key and passphrase are set.", typeName),
+	)
+	return false
 }
 
 // Metadata returns the provider type name.
@@ -55,15 +144,84 @@ func (p *PassboltProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "The base URL of the Passbolt instance (e.g., https://passbolt.example.com)",
 			},
 			"private_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The private key for Passbolt authentication",
+				Description: "The private key for Passbolt authentication. Can also be set with the PASSBOLT_PRIVATE_KEY environment variable, or read from the file named by PASSBOLT_PRIVATE_KEY_FILE",
 			},
 			"passphrase": schema.StringAttribute{
 				Required:    true,
 				Sensitive:   true,
 				Description: "The passphrase for the private key",
 			},
+			"require_shared": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Fail the apply if a created password is not shared with any group and would only exist in the personal space of the authenticated user",
+			},
+			"detect_concurrent_changes": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Fail updates and deletes of passwords modified in Passbolt since they were last read, instead of overwriting the changes",
+			},
+			"warn_on_duplicates": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Warn when a created password has the same name as an existing password in the same folder, which usually means it should have been imported",
+			},
+			"rollback_failed_creates": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Delete passwords and folders whose creation failed after they were stored in Passbolt, such as when sharing them fails, instead of leaving them behind",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Refuse every request that would modify the vault, so that the credentials can only be used for reading even if resources are configured by mistake",
+			},
+			"preflight_checks": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Capabilities verified when the provider is configured, all missing ones are reported in a single error before anything is changed. " +
+					"admin requires the authenticated user to be an administrator, the others require the server plugin of the same name",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(preflightCheckNames()...)),
+				},
+			},
+			"verify_server": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Before logging in, verify that the server can decrypt a challenge encrypted with its advertised OpenPGP key, as the Passbolt CLI does",
+			},
+			"skip_validation": schema.BoolAttribute{
+				Optional: true,
+				Description: "Disable the client-side validation of the URI format of passwords and leave it to the Passbolt server, for servers with custom constraints. " +
+					"The field lengths are still checked, they are the limits of the Passbolt database",
+			},
+			"normalize_values": schema.BoolAttribute{
+				Optional: true,
+				Description: "Trim the usernames and URIs of passwords and lowercase the scheme and host of URIs when writing them, " +
+					"and ignore such differences when reading them, so values edited in the web interface with stray whitespace do not show as changes",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of times a failed request to the Passbolt API is retried (default %d)", defaultMaxRetries),
+			},
+			"max_elapsed_time": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum time spent retrying a request, as a duration such as 90s or 5m (default %s)", defaultMaxElapsedTime),
+			},
+			"login_max_attempts": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of login attempts, login failures are often transient right after a server restart (default %d)", defaultLoginMaxAttempts),
+			},
+			"login_retry_delay": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The time to wait between login attempts, as a duration such as 5s or 1m (default %s)", defaultLoginRetryDelay),
+			},
+			"debug_http_trace_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Append traces of every request to the Passbolt API and its response to this file, for troubleshooting. " +
+					"Authentication headers, keys, encrypted secrets and bodies that are not JSON are redacted",
+			},
+			"retryable_status_codes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: fmt.Sprintf("The HTTP status codes of responses that are retried (default %v). Requests that are not idempotent, such as creations, are only retried on 429, or on 503 with a Retry-After header, as the server may have processed them", defaultRetryableStatusCodes),
+			},
 		},
 	}
 }
@@ -122,6 +280,20 @@ func (p *PassboltProvider) Configure(ctx context.Context, req provider.Configure
 		privateKey = config.PrivateKey.ValueString()
 	}
 
+	// Read the key from a file when it is only mounted as a file, such as in CI systems
+	if privateKeyFile := os.Getenv("PASSBOLT_PRIVATE_KEY_FILE"); privateKey == "" && privateKeyFile != "" {
+		content, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("private_key"),
+				"Unable to Read Passbolt Private Key File",
+				fmt.Sprintf("Cannot read the private key file set in PASSBOLT_PRIVATE_KEY_FILE: %s", err.Error()),
+			)
+			return
+		}
+		privateKey = string(content)
+	}
+
 	if !config.Passphrase.IsNull() {
 		passphrase = config.Passphrase.ValueString()
 	}
@@ -142,7 +314,8 @@ func (p *PassboltProvider) Configure(ctx context.Context, req provider.Configure
 			path.Root("private_key"),
 			"Missing Passbolt Private Key",
 			"The provider cannot create the Passbolt API client as there is a missing or empty value for the Passbolt private key. "+
-				"Set the private_key value in the configuration or use the PASSBOLT_PRIVATE_KEY environment variable. "+
+				"Set the private_key value in the configuration, use the PASSBOLT_PRIVATE_KEY environment variable "+
+				"or point the PASSBOLT_PRIVATE_KEY_FILE environment variable to a file containing the key. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -161,8 +334,107 @@ func (p *PassboltProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	// Build the retry policy for API requests
+	retryConfig := RetryConfig{
+		MaxRetries:           defaultMaxRetries,
+		MaxElapsedTime:       defaultMaxElapsedTime,
+		RetryableStatusCodes: defaultRetryableStatusCodes,
+	}
+
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				"The max_retries value cannot be negative.",
+			)
+		}
+		retryConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.MaxElapsedTime.IsNull() {
+		maxElapsedTime, err := time.ParseDuration(config.MaxElapsedTime.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_elapsed_time"),
+				"Invalid Max Elapsed Time",
+				fmt.Sprintf("The max_elapsed_time value must be a duration such as 90s or 5m: %s", err.Error()),
+			)
+		}
+		retryConfig.MaxElapsedTime = maxElapsedTime
+	}
+
+	if !config.RetryableStatusCodes.IsNull() {
+		var codes []int64
+		resp.Diagnostics.Append(config.RetryableStatusCodes.ElementsAs(ctx, &codes, false)...)
+
+		retryConfig.RetryableStatusCodes = make([]int, 0, len(codes))
+		for _, code := range codes {
+			retryConfig.RetryableStatusCodes = append(retryConfig.RetryableStatusCodes, int(code))
+		}
+	}
+
+	// Build the login retry policy, separate from the policy of regular requests
+	loginRetryConfig := LoginRetryConfig{
+		MaxAttempts: defaultLoginMaxAttempts,
+		Delay:       defaultLoginRetryDelay,
+	}
+
+	if !config.LoginMaxAttempts.IsNull() {
+		if config.LoginMaxAttempts.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("login_max_attempts"),
+				"Invalid Login Max Attempts",
+				"The login_max_attempts value must be at least 1.",
+			)
+		}
+		loginRetryConfig.MaxAttempts = int(config.LoginMaxAttempts.ValueInt64())
+	}
+
+	if !config.LoginRetryDelay.IsNull() {
+		loginRetryDelay, err := time.ParseDuration(config.LoginRetryDelay.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("login_retry_delay"),
+				"Invalid Login Retry Delay",
+				fmt.Sprintf("The login_retry_delay value must be a duration such as 5s or 1m: %s", err.Error()),
+			)
+		}
+		loginRetryConfig.Delay = loginRetryDelay
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Trace every attempt, including the retried ones
+	var transport http.RoundTripper = http.DefaultTransport
+	if !config.DebugHTTPTraceFile.IsNull() {
+		traceTransport, err := newTraceTransport(transport, config.DebugHTTPTraceFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("debug_http_trace_file"),
+				"Invalid Debug HTTP Trace File",
+				fmt.Sprintf("Cannot open the HTTP trace file: %s", err.Error()),
+			)
+			return
+		}
+		transport = traceTransport
+	}
+
+	transport = newRetryTransport(transport, retryConfig)
+
+	// Refused requests are neither sent nor retried
+	if config.ReadOnly.ValueBool() {
+		transport = newReadOnlyTransport(transport)
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+	}
+
 	// Create the Passbolt API client
-	client, err := api.NewClient(nil, "", baseURL, privateKey, passphrase)
+	client, err := api.NewClient(httpClient, "", baseURL, privateKey, passphrase)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Passbolt API client",
@@ -171,8 +443,20 @@ func (p *PassboltProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	// Verify the identity of the server before any secret is sent to it
+	if config.VerifyServer.ValueBool() {
+		_, _, err = client.SetupServerVerification(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to verify the Passbolt server",
+				fmt.Sprintf("The server could not prove that it holds the private key of its advertised OpenPGP key: %s", err.Error()),
+			)
+			return
+		}
+	}
+
 	// Login to Passbolt
-	err = client.Login(ctx)
+	err = login(ctx, client, loginRetryConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to login to Passbolt",
@@ -181,15 +465,77 @@ func (p *PassboltProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	// Make the client available during DataSource and Resource type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// Detect the capabilities of the server, resources needing a missing plugin fail with a clear error
+	capabilities, err := getCapabilities(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to detect Passbolt server capabilities",
+			fmt.Sprintf("Cannot read the server settings, all plugins are assumed to be enabled: %s", err.Error()),
+		)
+	}
+
+	// Report everything the run would miss at once, instead of failing resource by resource
+	if !config.PreflightChecks.IsNull() {
+		var checks []string
+		resp.Diagnostics.Append(config.PreflightChecks.ElementsAs(ctx, &checks, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		failures := runPreflightChecks(ctx, client, capabilities, checks)
+		if len(failures) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("preflight_checks"),
+				"Preflight checks failed",
+				"The Passbolt server or account lacks capabilities required by this configuration:\n\n- "+strings.Join(failures, "\n- "),
+			)
+			return
+		}
+	}
+
+	data := &PassboltProviderData{
+		Client:                  client,
+		RequireShared:           config.RequireShared.ValueBool(),
+		DetectConcurrentChanges: config.DetectConcurrentChanges.ValueBool(),
+		WarnOnDuplicates:        config.WarnOnDuplicates.ValueBool(),
+		RollbackFailedCreates:   config.RollbackFailedCreates.ValueBool(),
+		SkipValidation:          config.SkipValidation.ValueBool(),
+		NormalizeValues:         config.NormalizeValues.ValueBool(),
+		ReadOnly:                config.ReadOnly.ValueBool(),
+		Retry:                   retryConfig,
+		LoginRetry:              loginRetryConfig,
+		Capabilities:            capabilities,
+		Lookups:                 NewLookups(client),
+		Key:                     NewUnlockedKey(privateKey, passphrase),
+	}
+
+	// Make the client and the run-level settings available during DataSource and Resource type Configure methods.
+	resp.DataSourceData = data
+	resp.ResourceData = data
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *PassboltProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewPasswordsDataSource,
+		NewGroupDataSource,
+		NewUserDataSource,
+		NewFolderIDDataSource,
+		NewFolderPathsDataSource,
+		NewExpiredPasswordsDataSource,
+		NewUserStatisticsDataSource,
+		NewServerFeaturesDataSource,
+		NewUserGroupsDataSource,
+		NewGroupPasswordsDataSource,
+		NewOversharedPasswordsDataSource,
+		NewPendingUsersDataSource,
+		NewTagDataSource,
+		NewCommentsDataSource,
+		NewSecretRotationDataSource,
+		NewShareDataSource,
+		NewAssertGroupAccessDataSource,
+		NewGroupUsersDataSource,
+		NewSecretAccessDataSource,
 	}
 }
 
@@ -198,5 +544,19 @@ func (p *PassboltProvider) Resources(_ context.Context) []func() resource.Resour
 	return []func() resource.Resource{
 		NewPasswordResource,
 		NewFolderResource,
+		NewGroupResource,
+		NewUserResource,
+		NewResourcePermissionResource,
+		NewServerSettingResource,
+		NewFolderStructureResource,
+		NewPasswordMetadataResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *PassboltProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewResourceURLFunction,
+		NewCheckPasswordFunction,
 	}
 }
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// resourceMetadata is the organizational metadata of a resource that is not part of its configuration.
+type resourceMetadata struct {
+	Tags     []api.Tag
+	Comments []api.Comment
+}
+
+// resourceTagsRequest is the body of the request replacing the tags of a resource.
+type resourceTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// getResourceMetadata reads the tags and comments of a resource, tags are only read when the tags plugin is enabled.
+func getResourceMetadata(ctx context.Context, client *api.Client, data *PassboltProviderData, resourceID string) (*resourceMetadata, error) {
+	metadata := &resourceMetadata{}
+
+	if data.Capabilities.HasPlugin(pluginTags) {
+		resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+			FilterHasID: []string{resourceID},
+			ContainTags: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting tags: %w", err)
+		}
+		if len(resources) > 0 {
+			metadata.Tags = resources[0].Tags
+		}
+	}
+
+	comments, err := client.GetComments(ctx, resourceID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting comments: %w", err)
+	}
+	metadata.Comments = comments
+
+	return metadata, nil
+}
+
+// restoreResourceMetadata copies tags and comments onto a resource.
+// Comments are recreated as the authenticated user, since Passbolt does not allow writing them on behalf of others.
+func restoreResourceMetadata(ctx context.Context, client *api.Client, resourceID string, metadata *resourceMetadata) error {
+	if len(metadata.Tags) > 0 {
+		slugs := make([]string, 0, len(metadata.Tags))
+		for _, tag := range metadata.Tags {
+			slugs = append(slugs, tag.Slug)
+		}
+
+		err := setResourceTags(ctx, client, resourceID, slugs)
+		if err != nil {
+			return fmt.Errorf("setting tags: %w", err)
+		}
+	}
+
+	return restoreComments(ctx, client, resourceID, "", metadata.Comments)
+}
+
+// restoreComments recreates a thread of comments oldest first, keeping the replies under their parent.
+func restoreComments(ctx context.Context, client *api.Client, resourceID, parentID string, comments []api.Comment) error {
+	sorted := append([]api.Comment(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Created == nil || sorted[j].Created == nil {
+			return false
+		}
+		return sorted[i].Created.Before(sorted[j].Created.Time)
+	})
+
+	for _, comment := range sorted {
+		created, err := client.CreateComment(ctx, resourceID, api.Comment{
+			ParentID:     parentID,
+			ForeignModel: "Resource",
+			Content:      comment.Content,
+		})
+		if err != nil {
+			return fmt.Errorf("creating comment: %w", err)
+		}
+
+		err = restoreComments(ctx, client, resourceID, created.ID, comment.Children)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getResourceTags returns the slugs of the tags of a resource visible to the authenticated user, sorted.
+func getResourceTags(ctx context.Context, client *api.Client, resourceID string) ([]string, error) {
+	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+		FilterHasID: []string{resourceID},
+		ContainTags: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resources) == 0 {
+		return []string{}, nil
+	}
+	return sortedTagSlugs(resources[0].Tags), nil
+}
+
+// sortedTagSlugs returns the sorted slugs of tags, never returning nil.
+func sortedTagSlugs(tags []api.Tag) []string {
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// setResourceTags replaces the tags of a resource.
+func setResourceTags(ctx context.Context, client *api.Client, resourceID string, slugs []string) error {
+	_, err := doCustomRequest(ctx, client, "POST", "/tags/"+resourceID+".json", resourceTagsRequest{Tags: slugs}, nil)
+	return err
+}
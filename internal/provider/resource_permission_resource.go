@@ -0,0 +1,423 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ResourcePermissionResource{}
+	_ resource.ResourceWithConfigure   = &ResourcePermissionResource{}
+	_ resource.ResourceWithImportState = &ResourcePermissionResource{}
+)
+
+// NewResourcePermissionResource is a helper function to simplify the provider implementation.
+func NewResourcePermissionResource() resource.Resource {
+	return &ResourcePermissionResource{}
+}
+
+// ResourcePermissionResource is the resource implementation.
+type ResourcePermissionResource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// ResourcePermissionResourceModel describes the resource data model.
+type ResourcePermissionResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	ResourceID   types.String   `tfsdk:"resource_id"`
+	ARO          types.String   `tfsdk:"aro"`
+	AROID        types.String   `tfsdk:"aro_id"`
+	Permission   types.String   `tfsdk:"permission"`
+	PermissionID types.String   `tfsdk:"permission_id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ResourcePermissionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.data = data
+}
+
+// Metadata returns the resource type name.
+func (r *ResourcePermissionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_permission"
+}
+
+// Schema defines the schema for the resource.
+func (r *ResourcePermissionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A single permission of a user or group on a password resource",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the permission, in the form resource_id/aro_id",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"permission_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the permission in Passbolt, used to update or revoke exactly this permission",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the password resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aro": schema.StringAttribute{
+				Required:    true,
+				Description: "The type of the access request object, either User or Group",
+				Validators: []validator.String{
+					stringvalidator.OneOf("User", "Group"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aro_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the user or group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permission": schema.StringAttribute{
+				Required:    true,
+				Description: "The permission granted, one of read, update or owner",
+				Validators: []validator.String{
+					stringvalidator.OneOf(permissionNames()...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ResourcePermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_resource_permission", &resp.Diagnostics) {
+		return
+	}
+
+	var plan ResourcePermissionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Do not silently take over a permission that is already granted
+	permission, err := r.getPermission(ctx, plan.ResourceID.ValueString(), plan.AROID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading resource permissions",
+			"Could not read resource permissions, unexpected error: "+describeError(err),
+		)
+		return
+	}
+	if permission != nil {
+		resp.Diagnostics.AddError(
+			"Permission already exists",
+			fmt.Sprintf("%s '%s' already has a permission on resource '%s', import it with the ID %s/%s.",
+				plan.ARO.ValueString(), plan.AROID.ValueString(), plan.ResourceID.ValueString(),
+				plan.ResourceID.ValueString(), plan.AROID.ValueString()),
+		)
+		return
+	}
+
+	// Share the resource, this also encrypts the secret for the new users
+	err = shareResource(ctx, r.data, plan.ResourceID.ValueString(), []helper.ShareOperation{
+		{
+			Type:  permissionTypes[plan.Permission.ValueString()],
+			ARO:   plan.ARO.ValueString(),
+			AROID: plan.AROID.ValueString(),
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot share resource", err.Error())
+		return
+	}
+
+	// Set the computed values
+	plan.ID = types.StringValue(plan.ResourceID.ValueString() + "/" + plan.AROID.ValueString())
+	plan.PermissionID = types.StringNull()
+	permission, err = r.getPermission(ctx, plan.ResourceID.ValueString(), plan.AROID.ValueString())
+	if err == nil && permission != nil {
+		plan.PermissionID = types.StringValue(permission.ID)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ResourcePermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_resource_permission", &resp.Diagnostics) {
+		return
+	}
+
+	var state ResourcePermissionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	permission, err := r.getPermission(ctx, state.ResourceID.ValueString(), state.AROID.ValueString())
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		permission, err = r.getPermission(ctx, state.ResourceID.ValueString(), state.AROID.ValueString())
+	}
+	if err != nil {
+		// The resource itself was deleted outside of Terraform
+		if isResourceNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading resource permissions",
+			"Could not read resource permissions, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// The permission was revoked outside of Terraform
+	if permission == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update the state with the current values from Passbolt
+	state.ARO = types.StringValue(permission.ARO)
+	state.Permission = types.StringValue(permissionName(permission.Type))
+	state.PermissionID = types.StringValue(permission.ID)
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ResourcePermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_resource_permission", &resp.Diagnostics) {
+		return
+	}
+
+	var plan ResourcePermissionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Only the permission type can change, everything else forces a replacement
+	var err error
+	if plan.PermissionID.IsNull() || plan.PermissionID.IsUnknown() {
+		err = shareResource(ctx, r.data, plan.ResourceID.ValueString(), []helper.ShareOperation{
+			{
+				Type:  permissionTypes[plan.Permission.ValueString()],
+				ARO:   plan.ARO.ValueString(),
+				AROID: plan.AROID.ValueString(),
+			},
+		})
+	} else {
+		err = updatePermission(ctx, r.client, plan.ResourceID.ValueString(), r.permissionEntry(plan, permissionTypes[plan.Permission.ValueString()]))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot share resource", err.Error())
+		return
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ResourcePermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_resource_permission", &resp.Diagnostics) {
+		return
+	}
+
+	var state ResourcePermissionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Revoke the permission, states written before permission IDs were recorded look it up by user or group
+	var err error
+	if state.PermissionID.IsNull() || state.PermissionID.IsUnknown() {
+		err = shareResource(ctx, r.data, state.ResourceID.ValueString(), []helper.ShareOperation{
+			{
+				Type:  -1,
+				ARO:   state.ARO.ValueString(),
+				AROID: state.AROID.ValueString(),
+			},
+		})
+	} else {
+		entry := r.permissionEntry(state, permissionTypes[state.Permission.ValueString()])
+		entry.Delete = true
+		err = updatePermission(ctx, r.client, state.ResourceID.ValueString(), entry)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting permission",
+			"Could not delete permission, unexpected error: "+describeError(err),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing permission using the resource_id/aro_id composite key.
+func (r *ResourcePermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !requireConfigured(r.data, "passbolt_resource_permission", &resp.Diagnostics) {
+		return
+	}
+
+	resourceID, aroID, ok := strings.Cut(req.ID, "/")
+	if !ok || resourceID == "" || aroID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier in the form resource_id/aro_id, got: %s", req.ID),
+		)
+		return
+	}
+
+	permission, err := r.getPermission(ctx, resourceID, aroID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading resource permissions",
+			"Could not read resource permissions, unexpected error: "+describeError(err),
+		)
+		return
+	}
+	if permission == nil {
+		resp.Diagnostics.AddError(
+			"Permission not found",
+			fmt.Sprintf("No permission on resource '%s' for '%s'", resourceID, aroID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_id"), resourceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("aro"), permission.ARO)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("aro_id"), aroID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("permission"), permissionName(permission.Type))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("permission_id"), permission.ID)...)
+}
+
+// getPermission returns the permission of a user or group on a resource, or nil if there is none.
+func (r *ResourcePermissionResource) getPermission(ctx context.Context, resourceID, aroID string) (*api.Permission, error) {
+	permissions, err := r.client.GetResourcePermissions(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, permission := range permissions {
+		if permission.AROForeignKey == aroID {
+			return &permission, nil
+		}
+	}
+	return nil, nil
+}
+
+// permissionEntry returns the share entry updating the recorded permission to the given type.
+func (r *ResourcePermissionResource) permissionEntry(model ResourcePermissionResourceModel, permissionType int) api.Permission {
+	return api.Permission{
+		ID:            model.PermissionID.ValueString(),
+		ACO:           "Resource",
+		ACOForeignKey: model.ResourceID.ValueString(),
+		ARO:           model.ARO.ValueString(),
+		AROForeignKey: model.AROID.ValueString(),
+		Type:          permissionType,
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &ResourceURLFunction{}
+
+// NewResourceURLFunction is a helper function to simplify the provider implementation.
+func NewResourceURLFunction() function.Function {
+	return &ResourceURLFunction{}
+}
+
+// ResourceURLFunction is the function implementation.
+type ResourceURLFunction struct{}
+
+// Metadata returns the function name.
+func (f *ResourceURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resource_url"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *ResourceURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the web UI link of a password resource",
+		Description: "Returns the link opening the given password resource in the Passbolt web UI.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base_url",
+				Description: "The base URL of the Passbolt instance (e.g., https://passbolt.example.com)",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The unique identifier of the password resource",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the link from the arguments.
+func (f *ResourceURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var baseURL, id string
+	resp.Error = req.Arguments.Get(ctx, &baseURL, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		resp.Error = function.NewArgumentFuncError(0, "base_url must be an absolute URL such as https://passbolt.example.com")
+		return
+	}
+	if id == "" {
+		resp.Error = function.NewArgumentFuncError(1, "id cannot be empty")
+		return
+	}
+
+	link := strings.TrimSuffix(baseURL, "/") + "/app/passwords/view/" + url.PathEscape(id)
+	resp.Error = resp.Result.Set(ctx, link)
+}
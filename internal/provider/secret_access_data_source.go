@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SecretAccessDataSource{}
+	_ datasource.DataSourceWithConfigure = &SecretAccessDataSource{}
+)
+
+// NewSecretAccessDataSource is a helper function to simplify the provider implementation.
+func NewSecretAccessDataSource() datasource.DataSource {
+	return &SecretAccessDataSource{}
+}
+
+// SecretAccessDataSource is the data source implementation.
+type SecretAccessDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// SecretAccessDataSourceModel describes the data source data model.
+type SecretAccessDataSourceModel struct {
+	ResourceID types.String        `tfsdk:"resource_id"`
+	Holders    []SecretHolderModel `tfsdk:"holders"`
+	Usernames  []types.String      `tfsdk:"usernames"`
+}
+
+// SecretHolderModel describes a user holding an encrypted copy of a secret.
+type SecretHolderModel struct {
+	UserID     types.String   `tfsdk:"user_id"`
+	Username   types.String   `tfsdk:"username"`
+	Permission types.String   `tfsdk:"permission"`
+	Direct     types.Bool     `tfsdk:"direct"`
+	Groups     []types.String `tfsdk:"groups"`
+}
+
+// secretHolder accumulates the permissions granting a user access to a secret.
+type secretHolder struct {
+	username   string
+	permission int
+	direct     bool
+	groups     []string
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SecretAccessDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *SecretAccessDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_access"
+}
+
+// Schema defines the schema for the data source.
+func (d *SecretAccessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The users holding an encrypted copy of the secret of a password resource, that is every user who could know it. " +
+			"Passbolt keeps one copy per user with access, directly or through a group. Requires an administrator account with access to the resource",
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the password resource",
+			},
+			"holders": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The users holding a copy of the secret, sorted by username",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the user",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username (email) of the user",
+						},
+						"permission": schema.StringAttribute{
+							Computed:    true,
+							Description: "The highest permission of the user on the resource, one of read, update or owner",
+						},
+						"direct": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the resource is shared with the user directly",
+						},
+						"groups": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The names of the groups the user has access through, sorted",
+						},
+					},
+				},
+			},
+			"usernames": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The usernames of the holders, sorted",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SecretAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_secret_access", &resp.Diagnostics) {
+		return
+	}
+	if !requireAdmin(ctx, d.data, "passbolt_secret_access", &resp.Diagnostics) {
+		return
+	}
+
+	var state SecretAccessDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The server only returns the copy of the authenticated user, the other copies follow the permissions
+	permissions, err := getPermissionsWithARO(ctx, d.client, state.ResourceID.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permissions",
+			"Could not read permissions, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Groups are expanded to their current members, who each hold a copy
+	groups, err := d.client.GetGroups(ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers:     true,
+		ContainGroupsUsersUser: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading groups",
+			"Could not read groups, unexpected error: "+describeError(err),
+		)
+		return
+	}
+	groupsByID := make(map[string]api.Group, len(groups))
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	holders := make(map[string]*secretHolder)
+	holder := func(userID, username string, permission int) *secretHolder {
+		h, ok := holders[userID]
+		if !ok {
+			h = &secretHolder{username: username}
+			holders[userID] = h
+		}
+		if permission > h.permission {
+			h.permission = permission
+		}
+		return h
+	}
+	for _, permission := range permissions {
+		switch permission.ARO {
+		case "User":
+			holder(permission.AROForeignKey, permission.aroName(), permission.Type).direct = true
+		case "Group":
+			group := groupsByID[permission.AROForeignKey]
+			for _, membership := range group.GroupUsers {
+				h := holder(membership.UserID, membership.User.Username, permission.Type)
+				h.groups = append(h.groups, group.Name)
+			}
+		}
+	}
+
+	state.Holders = make([]SecretHolderModel, 0, len(holders))
+	usernames := make([]string, 0, len(holders))
+	for userID, h := range holders {
+		state.Holders = append(state.Holders, SecretHolderModel{
+			UserID:     types.StringValue(userID),
+			Username:   types.StringValue(h.username),
+			Permission: types.StringValue(permissionName(h.permission)),
+			Direct:     types.BoolValue(h.direct),
+			Groups:     sortedStringValues(h.groups),
+		})
+		usernames = append(usernames, h.username)
+	}
+	sort.Slice(state.Holders, func(i, j int) bool {
+		return state.Holders[i].Username.ValueString() < state.Holders[j].Username.ValueString()
+	})
+	state.Usernames = sortedStringValues(usernames)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
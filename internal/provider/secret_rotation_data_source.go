@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &SecretRotationDataSource{}
+	_ datasource.DataSourceWithConfigure = &SecretRotationDataSource{}
+)
+
+// NewSecretRotationDataSource is a helper function to simplify the provider implementation.
+func NewSecretRotationDataSource() datasource.DataSource {
+	return &SecretRotationDataSource{}
+}
+
+// SecretRotationDataSource is the data source implementation.
+type SecretRotationDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// SecretRotationDataSourceModel describes the data source data model.
+type SecretRotationDataSourceModel struct {
+	ResourceID       types.String `tfsdk:"resource_id"`
+	SecretModified   types.String `tfsdk:"secret_modified"`
+	MetadataModified types.String `tfsdk:"metadata_modified"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *SecretRotationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *SecretRotationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_rotation"
+}
+
+// Schema defines the schema for the data source.
+func (d *SecretRotationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "When the secret of a password resource was last changed, separately from its metadata. The secret itself is not decrypted",
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the password resource",
+			},
+			"secret_modified": schema.StringAttribute{
+				Computed: true,
+				Description: "The time the secret was last changed. Editing the name, username, URI or a cleartext description does not change it, " +
+					"when the secret was never rotated since the authenticated user got access it is the time access was granted",
+			},
+			"metadata_modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the password resource was last modified, including metadata only edits",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *SecretRotationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_secret_rotation", &resp.Diagnostics) {
+		return
+	}
+
+	var state SecretRotationDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resource, err := d.client.GetResource(ctx, state.ResourceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading password",
+			"Could not read password, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Every secret copy is rewritten when the value changes, the one of the authenticated user is enough
+	secret, err := d.client.GetSecret(ctx, state.ResourceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading secret",
+			"Could not read secret, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.SecretModified = types.StringNull()
+	if secret.Modified != nil {
+		state.SecretModified = types.StringValue(secret.Modified.UTC().Format(time.RFC3339))
+	}
+	state.MetadataModified = types.StringNull()
+	if resource.Modified != nil {
+		state.MetadataModified = types.StringValue(resource.Modified.UTC().Format(time.RFC3339))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ServerFeaturesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ServerFeaturesDataSource{}
+)
+
+// NewServerFeaturesDataSource is a helper function to simplify the provider implementation.
+func NewServerFeaturesDataSource() datasource.DataSource {
+	return &ServerFeaturesDataSource{}
+}
+
+// ServerFeaturesDataSource is the data source implementation.
+type ServerFeaturesDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// ServerFeaturesDataSourceModel describes the data source data model.
+type ServerFeaturesDataSourceModel struct {
+	Edition        types.String          `tfsdk:"edition"`
+	Version        types.String          `tfsdk:"version"`
+	Plugins        map[string]types.Bool `tfsdk:"plugins"`
+	Folders        types.Bool            `tfsdk:"folders"`
+	Tags           types.Bool            `tfsdk:"tags"`
+	TOTP           types.Bool            `tfsdk:"totp"`
+	PasswordExpiry types.Bool            `tfsdk:"password_expiry"`
+	Metadata       types.Bool            `tfsdk:"metadata"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ServerFeaturesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *ServerFeaturesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_features"
+}
+
+// Schema defines the schema for the data source.
+func (d *ServerFeaturesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"edition": schema.StringAttribute{
+				Computed:    true,
+				Description: "The edition of the server, such as ce or pro",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The version of the server",
+			},
+			"plugins": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.BoolType,
+				Description: "The plugins installed on the server keyed by name, with whether they are enabled",
+			},
+			"folders": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether folders are enabled",
+			},
+			"tags": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether tags are enabled",
+			},
+			"totp": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether TOTP resource types are enabled",
+			},
+			"password_expiry": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether password expiry is enabled",
+			},
+			"metadata": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether encrypted metadata (v5 resources) is enabled",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ServerFeaturesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_server_features", &resp.Diagnostics) {
+		return
+	}
+
+	var state ServerFeaturesDataSourceModel
+
+	// Read the settings again, so that a failed detection at configure time surfaces as an error here
+	capabilities, err := getCapabilities(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading server settings",
+			"Could not read server settings, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.Edition = types.StringValue(capabilities.Edition)
+	state.Version = types.StringValue(capabilities.Version)
+	state.Plugins = make(map[string]types.Bool, len(capabilities.Plugins))
+	for name, enabled := range capabilities.Plugins {
+		state.Plugins[name] = types.BoolValue(enabled)
+	}
+	state.Folders = types.BoolValue(capabilities.HasPlugin(pluginFolders))
+	state.Tags = types.BoolValue(capabilities.HasPlugin(pluginTags))
+	state.TOTP = types.BoolValue(capabilities.HasPlugin(pluginTOTP))
+	state.PasswordExpiry = types.BoolValue(capabilities.HasPlugin(pluginPasswordExpiry))
+	state.Metadata = types.BoolValue(capabilities.HasPlugin(pluginMetadata))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// serverSettingEndpoints are the API endpoints of the admin settings keyed by setting name.
+var serverSettingEndpoints = map[string]string{
+	"email_notifications":      "/settings/emails/notifications.json",
+	"locale":                   "/locale/settings.json",
+	"password_expiry":          "/password-expiry/settings.json",
+	"password_policies":        "/password-policies/settings.json",
+	"self_registration":        "/self-registration/settings.json",
+	"user_passphrase_policies": "/user-passphrase-policies/settings.json",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ServerSettingResource{}
+	_ resource.ResourceWithConfigure   = &ServerSettingResource{}
+	_ resource.ResourceWithImportState = &ServerSettingResource{}
+	_ resource.ResourceWithModifyPlan  = &ServerSettingResource{}
+)
+
+// NewServerSettingResource is a helper function to simplify the provider implementation.
+func NewServerSettingResource() resource.Resource {
+	return &ServerSettingResource{}
+}
+
+// ServerSettingResource is the resource implementation.
+type ServerSettingResource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// ServerSettingResourceModel describes the resource data model.
+type ServerSettingResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Value    types.String   `tfsdk:"value"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ServerSettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.data = data
+}
+
+// Metadata returns the resource type name.
+func (r *ServerSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_setting"
+}
+
+// Schema defines the schema for the resource.
+func (r *ServerSettingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An admin setting of the Passbolt server. Destroying the resource leaves the setting as it is on the server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the setting, same as its name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The name of the setting, one of %v", serverSettingNames()),
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverSettingNames()...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required: true,
+				Description: "The value of the setting as a JSON object, such as jsonencode({ ... }). " +
+					"Only the keys set here are compared with the server, other keys returned by the server are ignored",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ServerSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_server_setting", &resp.Diagnostics) {
+		return
+	}
+
+	var plan ServerSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.saveSetting(ctx, plan.Name.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error saving setting",
+			fmt.Sprintf("Could not save setting '%s', unexpected error: %s", plan.Name.ValueString(), describeError(err)),
+		)
+		return
+	}
+
+	// Set the computed values
+	plan.ID = plan.Name
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ServerSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_server_setting", &resp.Diagnostics) {
+		return
+	}
+
+	var state ServerSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	current, err := r.getSetting(ctx, state.Name.ValueString())
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		current, err = r.getSetting(ctx, state.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading setting",
+			fmt.Sprintf("Could not read setting '%s', unexpected error: %s", state.Name.ValueString(), describeError(err)),
+		)
+		return
+	}
+
+	// Only compare the keys managed by Terraform, the server adds identifiers and timestamps
+	var declared map[string]interface{}
+	if !state.Value.IsNull() && json.Unmarshal([]byte(state.Value.ValueString()), &declared) == nil {
+		projected := make(map[string]interface{}, len(declared))
+		for key := range declared {
+			if value, ok := current[key]; ok {
+				projected[key] = value
+			}
+		}
+		current = projected
+	}
+
+	if !reflect.DeepEqual(current, declared) {
+		value, err := json.Marshal(current)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading setting", err.Error())
+			return
+		}
+		state.Value = types.StringValue(string(value))
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ServerSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_server_setting", &resp.Diagnostics) {
+		return
+	}
+
+	var plan ServerSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.saveSetting(ctx, plan.Name.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error saving setting",
+			fmt.Sprintf("Could not save setting '%s', unexpected error: %s", plan.Name.ValueString(), describeError(err)),
+		)
+		return
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan fails at plan time when server settings would be changed without an administrator account.
+func (r *ServerSettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if plansChange(req) {
+		requireAdmin(ctx, r.data, "passbolt_server_setting", &resp.Diagnostics)
+	}
+}
+
+// Delete removes the setting from the Terraform state, the server keeps its current value.
+func (r *ServerSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_server_setting", &resp.Diagnostics) {
+		return
+	}
+
+	var state ServerSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Setting left unchanged",
+		fmt.Sprintf("Setting '%s' is no longer managed by Terraform, its current value is kept on the server.", state.Name.ValueString()),
+	)
+}
+
+// ImportState imports an existing setting by its name.
+func (r *ServerSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, ok := serverSettingEndpoints[req.ID]; !ok {
+		resp.Diagnostics.AddError(
+			"Unknown setting",
+			fmt.Sprintf("Setting '%s' is not supported, expected one of %v", req.ID, serverSettingNames()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// getSetting returns the current value of a setting.
+func (r *ServerSettingResource) getSetting(ctx context.Context, name string) (map[string]interface{}, error) {
+	msg, err := doCustomRequest(ctx, r.client, "GET", serverSettingEndpoints[name], nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var value map[string]interface{}
+	err = json.Unmarshal(msg.Body, &value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// saveSetting saves the value of a setting.
+func (r *ServerSettingResource) saveSetting(ctx context.Context, name, value string) error {
+	var body map[string]interface{}
+	err := json.Unmarshal([]byte(value), &body)
+	if err != nil {
+		return fmt.Errorf("value must be a JSON object: %w", err)
+	}
+
+	_, err = doCustomRequest(ctx, r.client, "POST", serverSettingEndpoints[name], body, nil)
+	return err
+}
+
+// serverSettingNames returns the names of the supported settings, sorted alphabetically.
+func serverSettingNames() []string {
+	names := make([]string, 0, len(serverSettingEndpoints))
+	for name := range serverSettingEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
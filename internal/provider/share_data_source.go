@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ShareDataSource{}
+	_ datasource.DataSourceWithConfigure = &ShareDataSource{}
+)
+
+// NewShareDataSource is a helper function to simplify the provider implementation.
+func NewShareDataSource() datasource.DataSource {
+	return &ShareDataSource{}
+}
+
+// ShareDataSource is the data source implementation.
+type ShareDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// ShareDataSourceModel describes the data source data model.
+type ShareDataSourceModel struct {
+	ResourceID  types.String      `tfsdk:"resource_id"`
+	FolderID    types.String      `tfsdk:"folder_id"`
+	Permissions []SharePermission `tfsdk:"permissions"`
+	Users       map[string]string `tfsdk:"users"`
+	Groups      map[string]string `tfsdk:"groups"`
+}
+
+// SharePermission describes a single permission on a password resource or folder.
+type SharePermission struct {
+	ID         types.String `tfsdk:"id"`
+	ARO        types.String `tfsdk:"aro"`
+	AROID      types.String `tfsdk:"aro_id"`
+	AROName    types.String `tfsdk:"aro_name"`
+	Type       types.Int64  `tfsdk:"type"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+// getPermissionsOptions are the query parameters of the resource permissions index.
+type getPermissionsOptions struct {
+	ContainUserProfile bool `url:"contain[user.profile],omitempty"`
+	ContainGroup       bool `url:"contain[group],omitempty"`
+}
+
+// folderWithPermissions is a folder including the users and groups of its permissions.
+type folderWithPermissions struct {
+	api.Folder
+	Permissions []permissionWithARO `json:"permissions,omitempty"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ShareDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *ShareDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_share"
+}
+
+// Schema defines the schema for the data source.
+func (d *ShareDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The permissions of a password resource or folder, both with IDs and with names, for writing checks about who has access",
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The unique identifier of the password resource, exactly one of resource_id and folder_id must be set",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("folder_id")),
+				},
+			},
+			"folder_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The unique identifier of the folder, exactly one of resource_id and folder_id must be set",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of permissions, groups first, each sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the permission",
+						},
+						"aro": schema.StringAttribute{
+							Computed:    true,
+							Description: "The kind of access request object, User or Group",
+						},
+						"aro_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the user or group",
+						},
+						"aro_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username of the user or the name of the group",
+						},
+						"type": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The Passbolt permission type, 1 for read, 7 for update and 15 for owner",
+						},
+						"permission": schema.StringAttribute{
+							Computed:    true,
+							Description: "The permission name, one of read, update or owner",
+						},
+					},
+				},
+			},
+			"users": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The permission names of the users with direct access, keyed by username",
+			},
+			"groups": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The permission names of the groups with access, keyed by group name",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ShareDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_share", &resp.Diagnostics) {
+		return
+	}
+
+	var state ShareDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissions, err := getPermissionsWithARO(ctx, d.client, state.ResourceID.ValueString(), state.FolderID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permissions",
+			"Could not read permissions, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	sort.SliceStable(permissions, func(i, j int) bool {
+		if permissions[i].ARO != permissions[j].ARO {
+			return permissions[i].ARO == "Group"
+		}
+		return permissions[i].aroName() < permissions[j].aroName()
+	})
+
+	state.Permissions = make([]SharePermission, 0, len(permissions))
+	state.Users = make(map[string]string)
+	state.Groups = make(map[string]string)
+	for _, permission := range permissions {
+		state.Permissions = append(state.Permissions, SharePermission{
+			ID:         types.StringValue(permission.ID),
+			ARO:        types.StringValue(permission.ARO),
+			AROID:      types.StringValue(permission.AROForeignKey),
+			AROName:    types.StringValue(permission.aroName()),
+			Type:       types.Int64Value(int64(permission.Type)),
+			Permission: types.StringValue(permissionName(permission.Type)),
+		})
+
+		switch permission.ARO {
+		case "User":
+			state.Users[permission.aroName()] = permissionName(permission.Type)
+		case "Group":
+			state.Groups[permission.aroName()] = permissionName(permission.Type)
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getPermissionsWithARO returns the permissions of a password resource, or of a folder when no resource ID is given,
+// including the users and groups they grant access to.
+func getPermissionsWithARO(ctx context.Context, client *api.Client, resourceID, folderID string) ([]permissionWithARO, error) {
+	var permissions []permissionWithARO
+	if resourceID != "" {
+		msg, err := doCustomRequest(ctx, client, "GET", "/permissions/resource/"+resourceID+".json", nil, getPermissionsOptions{
+			ContainUserProfile: true,
+			ContainGroup:       true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(msg.Body, &permissions)
+		if err != nil {
+			return nil, fmt.Errorf("decoding resource permissions: %w", err)
+		}
+		return permissions, nil
+	}
+
+	msg, err := doCustomRequest(ctx, client, "GET", "/folders/"+folderID+".json", nil, api.GetFolderOptions{
+		ContainPermissions:           true,
+		ContainPermissionUserProfile: true,
+		ContainPermissionGroup:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var folder folderWithPermissions
+	err = json.Unmarshal(msg.Body, &folder)
+	if err != nil {
+		return nil, fmt.Errorf("decoding folder permissions: %w", err)
+	}
+	return folder.Permissions, nil
+}
@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// sharePermissionsTimeout bounds the wait for the group permissions of shared resources to be recorded.
+const sharePermissionsTimeout = 30 * time.Second
+
+// sharePermissionsInterval is the delay between two permission checks.
+const sharePermissionsInterval = time.Second
+
+// permissionTypes are the Passbolt permission types keyed by their name in the configuration.
+var permissionTypes = map[string]int{
+	"read":   1,
+	"update": 7,
+	"owner":  15,
+}
+
+// legacySharePermission is the permission granted to the group set in share_group.
+const legacySharePermission = "update"
+
+// PasswordShareModel describes a share of a password resource with a group.
+type PasswordShareModel struct {
+	Group      types.String `tfsdk:"group"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+// passwordShareAttrTypes are the attribute types of a PasswordShareModel.
+var passwordShareAttrTypes = map[string]attr.Type{
+	"group":      types.StringType,
+	"permission": types.StringType,
+}
+
+// permissionNames returns the names of the permission types, sorted by permission level.
+func permissionNames() []string {
+	names := make([]string, 0, len(permissionTypes))
+	for name := range permissionTypes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return permissionTypes[names[i]] < permissionTypes[names[j]]
+	})
+	return names
+}
+
+// legacyShares converts a share_group value into the equivalent shares.
+func legacyShares(shareGroup types.String) []PasswordShareModel {
+	shares := []PasswordShareModel{}
+	if !shareGroup.IsNull() && !shareGroup.IsUnknown() && shareGroup.ValueString() != "" {
+		shares = append(shares, PasswordShareModel{
+			Group:      shareGroup,
+			Permission: types.StringValue(legacySharePermission),
+		})
+	}
+	return shares
+}
+
+// getGroupIDs returns the IDs of all groups keyed by group name.
+func getGroupIDs(ctx context.Context, lookups *Lookups) (map[string]string, error) {
+	groups, err := lookups.Groups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupIDs[group.Name] = group.ID
+	}
+	return groupIDs, nil
+}
+
+// resolveShares converts shares into share operations, failing for groups that do not exist.
+func resolveShares(shares []PasswordShareModel, groupIDs map[string]string) ([]helper.ShareOperation, error) {
+	operations := make([]helper.ShareOperation, 0, len(shares))
+	for _, share := range shares {
+		groupID, ok := groupIDs[share.Group.ValueString()]
+		if !ok {
+			return nil, fmt.Errorf("Group '%s' not found", share.Group.ValueString())
+		}
+
+		permissionType, ok := permissionTypes[share.Permission.ValueString()]
+		if !ok {
+			return nil, fmt.Errorf("Unknown permission '%s' for group '%s'", share.Permission.ValueString(), share.Group.ValueString())
+		}
+
+		operations = append(operations, helper.ShareOperation{
+			Type:  permissionType,
+			ARO:   "Group",
+			AROID: groupID,
+		})
+	}
+	return operations, nil
+}
+
+// shareChanges computes the operations turning the current permissions into the planned shares.
+// Only groups that were previously shared by Terraform are unshared, other permissions are left untouched.
+func shareChanges(current []api.Permission, previous, planned []helper.ShareOperation) []helper.ShareOperation {
+	currentTypes := make(map[string]int, len(current))
+	for _, permission := range current {
+		currentTypes[permission.ARO+"/"+permission.AROForeignKey] = permission.Type
+	}
+
+	changes := []helper.ShareOperation{}
+	wanted := make(map[string]bool, len(planned))
+	for _, operation := range planned {
+		key := operation.ARO + "/" + operation.AROID
+		wanted[key] = true
+		if currentTypes[key] != operation.Type {
+			changes = append(changes, operation)
+		}
+	}
+
+	for _, operation := range previous {
+		key := operation.ARO + "/" + operation.AROID
+		if _, exists := currentTypes[key]; exists && !wanted[key] {
+			changes = append(changes, helper.ShareOperation{
+				Type:  -1,
+				ARO:   operation.ARO,
+				AROID: operation.AROID,
+			})
+		}
+	}
+	return changes
+}
+
+// unmanagedShares returns the operations granting again the current permissions that are not managed by Terraform.
+// The permission of the given user is skipped, it owns the resources it creates.
+func unmanagedShares(current []api.Permission, managed []helper.ShareOperation, userID string) []helper.ShareOperation {
+	isManaged := make(map[string]bool, len(managed))
+	for _, operation := range managed {
+		isManaged[operation.ARO+"/"+operation.AROID] = true
+	}
+
+	operations := []helper.ShareOperation{}
+	for _, permission := range current {
+		if isManaged[permission.ARO+"/"+permission.AROForeignKey] {
+			continue
+		}
+		if permission.ARO == "User" && permission.AROForeignKey == userID {
+			continue
+		}
+		operations = append(operations, helper.ShareOperation{
+			Type:  permission.Type,
+			ARO:   permission.ARO,
+			AROID: permission.AROForeignKey,
+		})
+	}
+	return operations
+}
+
+// permissionName returns the configuration name of a Passbolt permission type.
+func permissionName(permissionType int) string {
+	for name, t := range permissionTypes {
+		if t == permissionType {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", permissionType)
+}
+
+// shareResource applies share operations to a resource like helper.ShareResource, but encrypts the secret with the
+// public keys memoized in the lookups so sharing many resources with the same group fetches the keys only once,
+// and with the unlocked key of the provider so the private key is not unlocked again for every user.
+func shareResource(ctx context.Context, data *PassboltProviderData, resourceID string, changes []helper.ShareOperation) error {
+	client := data.Client
+
+	oldPermissions, err := client.GetResourcePermissions(ctx, resourceID)
+	if err != nil {
+		return fmt.Errorf("getting resource permissions: %w", err)
+	}
+
+	permissionChanges, err := helper.GeneratePermissionChanges(oldPermissions, changes)
+	if err != nil {
+		return fmt.Errorf("generating resource permission changes: %w", err)
+	}
+
+	shareRequest := api.ResourceShareRequest{Permissions: permissionChanges, Secrets: []api.Secret{}}
+
+	// Changes to existing permissions, identified by their ID, grant nobody access and are sent as is
+	if !grantsAccess(permissionChanges) {
+		err = client.ShareResource(ctx, resourceID, shareRequest)
+		if err != nil {
+			return fmt.Errorf("sharing resource: %w", err)
+		}
+		return nil
+	}
+
+	// Only the users gaining access need the secret, the simulation tells which ones they are
+	simulationResult, err := client.SimulateShareResource(ctx, resourceID, shareRequest)
+	if err != nil {
+		return fmt.Errorf("simulating share resource: %w", err)
+	}
+
+	if len(simulationResult.Changes.Added) > 0 {
+		secret, err := client.GetSecret(ctx, resourceID)
+		if err != nil {
+			return fmt.Errorf("getting resource secret: %w", err)
+		}
+
+		secretData, err := data.Key.Decrypt(secret.Data)
+		if err != nil {
+			return fmt.Errorf("decrypting resource secret: %w", err)
+		}
+
+		for _, added := range simulationResult.Changes.Added {
+			publicKey, err := data.Lookups.PublicKey(ctx, added.User.ID)
+			if err != nil {
+				return err
+			}
+
+			encrypted, err := data.Key.EncryptFor(publicKey, secretData)
+			if err != nil {
+				return fmt.Errorf("encrypting secret for user %s: %w", added.User.ID, err)
+			}
+			shareRequest.Secrets = append(shareRequest.Secrets, api.Secret{
+				UserID: added.User.ID,
+				Data:   encrypted,
+			})
+		}
+	}
+
+	err = client.ShareResource(ctx, resourceID, shareRequest)
+	if err != nil {
+		return fmt.Errorf("sharing resource: %w", err)
+	}
+	return nil
+}
+
+// grantsAccess checks if permission changes add permissions, the other changes update or delete existing permissions.
+func grantsAccess(permissions []api.Permission) bool {
+	for _, permission := range permissions {
+		if permission.ID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// updatePermission changes or deletes a single permission of a resource by its ID, without reading the other permissions.
+// No user gains access this way, so no secret has to be encrypted.
+func updatePermission(ctx context.Context, client *api.Client, resourceID string, permission api.Permission) error {
+	err := client.ShareResource(ctx, resourceID, api.ResourceShareRequest{
+		Permissions: []api.Permission{permission},
+		Secrets:     []api.Secret{},
+	})
+	if err != nil {
+		return fmt.Errorf("sharing resource: %w", err)
+	}
+	return nil
+}
+
+// groupPermissionIDs returns the IDs of the group permissions keyed by group name, or null if the groups cannot be read.
+func groupPermissionIDs(ctx context.Context, lookups *Lookups, permissions []api.Permission) types.Map {
+	groups, err := lookups.Groups(ctx)
+	if err != nil {
+		return types.MapNull(types.StringType)
+	}
+
+	groupNames := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+
+	permissionIDs := make(map[string]attr.Value)
+	for _, permission := range permissions {
+		if name, ok := groupNames[permission.AROForeignKey]; ok && permission.ARO == "Group" {
+			permissionIDs[name] = types.StringValue(permission.ID)
+		}
+	}
+	return types.MapValueMust(types.StringType, permissionIDs)
+}
+
+// waitForSharePermissions polls until the resource has the permission of every group the operations granted access to.
+// The secrets of the group members cannot be checked, Passbolt only returns the secret of the authenticated user.
+func waitForSharePermissions(ctx context.Context, client *api.Client, resourceID string, operations []helper.ShareOperation) error {
+	ctx, cancel := context.WithTimeout(ctx, sharePermissionsTimeout)
+	defer cancel()
+
+	for _, operation := range operations {
+		if operation.ARO != "Group" || operation.Type < 0 {
+			continue
+		}
+
+		for {
+			permissions, err := client.GetResourcePermissions(ctx, resourceID)
+			if err == nil && hasGroupPermission(permissions, operation.AROID, operation.Type) {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("permission of group %s on resource %s not recorded after %s", operation.AROID, resourceID, sharePermissionsTimeout)
+			case <-time.After(sharePermissionsInterval):
+			}
+		}
+	}
+	return nil
+}
+
+// hasGroupPermission checks if the permissions include the given permission type of a group.
+func hasGroupPermission(permissions []api.Permission, groupID string, permissionType int) bool {
+	for _, permission := range permissions {
+		if permission.ARO == "Group" && permission.AROForeignKey == groupID && permission.Type == permissionType {
+			return true
+		}
+	}
+	return false
+}
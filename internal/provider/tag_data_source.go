@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &TagDataSource{}
+	_ datasource.DataSourceWithConfigure = &TagDataSource{}
+)
+
+// NewTagDataSource is a helper function to simplify the provider implementation.
+func NewTagDataSource() datasource.DataSource {
+	return &TagDataSource{}
+}
+
+// TagDataSource is the data source implementation.
+type TagDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// TagDataSourceModel describes the data source data model.
+type TagDataSourceModel struct {
+	Shared types.Bool `tfsdk:"shared"`
+	Tags   []TagModel `tfsdk:"tags"`
+}
+
+// TagModel describes a single tag.
+type TagModel struct {
+	ID         types.String `tfsdk:"id"`
+	Slug       types.String `tfsdk:"slug"`
+	Shared     types.Bool   `tfsdk:"shared"`
+	UsageCount types.Int64  `tfsdk:"usage_count"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *TagDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+
+	requirePlugin(data, pluginTags, "passbolt_tag", &resp.Diagnostics)
+}
+
+// Metadata returns the data source type name.
+func (d *TagDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+// Schema defines the schema for the data source.
+func (d *TagDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"shared": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return the shared tags when true or the personal tags when false, all tags are returned when unset",
+			},
+			"tags": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of tags visible to the authenticated user, sorted by slug",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the tag",
+						},
+						"slug": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the tag, shared tags start with #",
+						},
+						"shared": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the tag is shared with everyone who has access to the tagged resources",
+						},
+						"usage_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of password resources visible to the authenticated user with the tag",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *TagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_tag", &resp.Diagnostics) {
+		return
+	}
+
+	var state TagDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/tags.json", nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading tags",
+			"Could not read tags, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var tags []api.Tag
+	err = json.Unmarshal(msg.Body, &tags)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading tags",
+			"Could not decode tags, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// The tags index has no usage counts, they are counted on the resources
+	resources, err := d.client.GetResources(ctx, &api.GetResourcesOptions{
+		ContainTags: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading passwords",
+			"Could not read passwords, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	usage := make(map[string]int64)
+	for _, resource := range resources {
+		for _, tag := range resource.Tags {
+			usage[tag.ID]++
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].Slug != tags[j].Slug {
+			return tags[i].Slug < tags[j].Slug
+		}
+		return tags[i].ID < tags[j].ID
+	})
+
+	state.Tags = make([]TagModel, 0, len(tags))
+	for _, tag := range tags {
+		if !state.Shared.IsNull() && state.Shared.ValueBool() != tag.IsShared {
+			continue
+		}
+
+		state.Tags = append(state.Tags, TagModel{
+			ID:         types.StringValue(tag.ID),
+			Slug:       types.StringValue(tag.Slug),
+			Shared:     types.BoolValue(tag.IsShared),
+			UsageCount: types.Int64Value(usage[tag.ID]),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
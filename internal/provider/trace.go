@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces sensitive values in HTTP traces.
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are the headers whose values never appear in HTTP traces.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Csrf-Token":  true,
+}
+
+// sensitiveFields are the JSON fields whose values never appear in HTTP traces, such as encrypted secrets and keys.
+var sensitiveFields = map[string]bool{
+	"data":              true,
+	"armored_key":       true,
+	"password":          true,
+	"passphrase":        true,
+	"secret":            true,
+	"secrets":           true,
+	"token":             true,
+	"user_token_result": true,
+	"gpg_auth":          true,
+}
+
+// traceTransport is a http.RoundTripper writing sanitized traces of every request and response to a file.
+type traceTransport struct {
+	next http.RoundTripper
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// newTraceTransport wraps the given transport, appending traces to the file at the given path.
+func newTraceTransport(next http.RoundTripper, path string) (*traceTransport, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &traceTransport{
+		next: next,
+		file: file,
+	}, nil
+}
+
+// RoundTrip executes the request and records it along with its response.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var trace strings.Builder
+	fmt.Fprintf(&trace, "=== %s %s %s\n", time.Now().UTC().Format(time.RFC3339Nano), req.Method, req.URL.Redacted())
+	writeHeaders(&trace, "> ", req.Header)
+
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			content, _ := io.ReadAll(body)
+			body.Close()
+			writeBody(&trace, "> ", req.Header.Get("Content-Type"), content)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		fmt.Fprintf(&trace, "< error after %s: %s\n", time.Since(start).Round(time.Millisecond), err.Error())
+		t.write(trace.String())
+		return resp, err
+	}
+
+	fmt.Fprintf(&trace, "< %s (%s)\n", resp.Status, time.Since(start).Round(time.Millisecond))
+	writeHeaders(&trace, "< ", resp.Header)
+
+	// The body is read to be traced, so it is replaced with an in-memory copy for the caller
+	content, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(content))
+	if readErr != nil {
+		fmt.Fprintf(&trace, "< error reading body: %s\n", readErr.Error())
+	} else {
+		writeBody(&trace, "< ", resp.Header.Get("Content-Type"), content)
+	}
+
+	t.write(trace.String())
+	return resp, nil
+}
+
+// write appends a trace to the file, traces of concurrent requests are not interleaved.
+func (t *traceTransport) write(trace string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.file.WriteString(trace + "\n")
+}
+
+// writeHeaders writes the headers sorted by name, with the values of sensitive headers redacted.
+func writeHeaders(trace *strings.Builder, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[name] || strings.HasPrefix(name, "X-Gpgauth-") {
+			value = redacted
+		}
+		fmt.Fprintf(trace, "%s%s: %s\n", prefix, name, value)
+	}
+}
+
+// writeBody writes a body with the sensitive fields redacted, bodies that are not JSON are never written.
+func writeBody(trace *strings.Builder, prefix, contentType string, content []byte) {
+	if len(content) == 0 {
+		return
+	}
+
+	var value interface{}
+	if !strings.Contains(contentType, "json") || json.Unmarshal(content, &value) != nil {
+		fmt.Fprintf(trace, "%s%s body of %d bytes\n", prefix, redacted, len(content))
+		return
+	}
+
+	sanitized, err := json.MarshalIndent(redactJSON(value), prefix, "  ")
+	if err != nil {
+		fmt.Fprintf(trace, "%s%s body of %d bytes\n", prefix, redacted, len(content))
+		return
+	}
+	fmt.Fprintf(trace, "%s%s\n", prefix, sanitized)
+}
+
+// redactJSON replaces the values of sensitive fields and any OpenPGP armored block in a decoded JSON value.
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveFields[key] {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+		return v
+	case string:
+		if strings.Contains(v, "-----BEGIN PGP") {
+			return redacted
+		}
+		return v
+	default:
+		return v
+	}
+}
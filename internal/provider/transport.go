@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Default retry settings when the provider configuration does not set them.
+const (
+	defaultMaxRetries     = 3
+	defaultMaxElapsedTime = 2 * time.Minute
+	initialRetryInterval  = 500 * time.Millisecond
+	maxRetryInterval      = 30 * time.Second
+)
+
+// Default login retry settings when the provider configuration does not set them.
+const (
+	defaultLoginMaxAttempts = 3
+	defaultLoginRetryDelay  = 5 * time.Second
+)
+
+// defaultRetryableStatusCodes are the HTTP status codes retried by default.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryConfig describes how failed requests to the Passbolt API are retried.
+type RetryConfig struct {
+	MaxRetries           int
+	MaxElapsedTime       time.Duration
+	RetryableStatusCodes []int
+}
+
+// retryTransport is a http.RoundTripper retrying requests with an exponential backoff.
+// When the server tells how long to wait, such as with Retry-After, every request waits that long,
+// so that the concurrent operations of an apply do not keep hitting a throttled server.
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// newRetryTransport wraps the given transport with the retry behaviour described by config.
+func newRetryTransport(next http.RoundTripper, config RetryConfig) *retryTransport {
+	return &retryTransport{
+		next:   next,
+		config: config,
+	}
+}
+
+// RoundTrip executes the request, retrying it while the retry budget allows.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	interval := initialRetryInterval
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			// Request bodies can only be read once, so a fresh copy is needed for every retry
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		err := t.waitForPause(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if !t.shouldRetry(req, resp, err) || attempt >= t.config.MaxRetries {
+			return resp, err
+		}
+
+		// Without a way to read the body again, the request cannot be sent again
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		// The delay asked by the server takes precedence over the backoff
+		delay := interval
+		serverDelay, throttled := retryAfter(resp, time.Now())
+		if throttled {
+			delay = serverDelay
+		}
+
+		if t.config.MaxElapsedTime > 0 && time.Since(start)+delay > t.config.MaxElapsedTime {
+			return resp, err
+		}
+		if throttled {
+			t.pause(delay)
+		}
+
+		// Release the connection of the failed attempt before waiting
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+// pause delays every request of the transport by the given duration.
+func (t *retryTransport) pause(delay time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until := time.Now().Add(delay); until.After(t.pausedUntil) {
+		t.pausedUntil = until
+	}
+}
+
+// waitForPause waits until the delay asked by the server for any request has passed.
+func (t *retryTransport) waitForPause(ctx context.Context) error {
+	t.mu.Lock()
+	wait := time.Until(t.pausedUntil)
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryAfter returns the delay before the next attempt asked by the server in the Retry-After header, either in
+// seconds or as a date, or in the reset time of rate limit headers, either in seconds or as a Unix timestamp.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return nonNegative(date.Sub(now)), true
+		}
+	}
+
+	for _, header := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		seconds, err := strconv.ParseInt(resp.Header.Get(header), 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+
+		// Large values are timestamps rather than durations
+		if seconds > now.Unix()/2 {
+			return nonNegative(time.Unix(seconds, 0).Sub(now)), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
+
+// nonNegative returns the duration, or zero when it is negative.
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// shouldRetry checks if a request should be attempted again.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// Only retry connection errors when repeating the request has no side effects
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return true
+		default:
+			return false
+		}
+	}
+
+	retryable := false
+	for _, code := range t.config.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			retryable = true
+		}
+	}
+
+	// The server may have applied a request before a gateway failed, such as with a 502 or 504. Sending it again is
+	// only safe when that has no further effect, or when the server refused it without processing it
+	if retryable && !isIdempotent(req.Method) {
+		return resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != ""
+	}
+	return retryable
+}
+
+// isIdempotent checks if sending a request with the given method several times has the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// readOnlyPaths are the parts of the paths of requests that are allowed in read only mode despite their method,
+// they authenticate or simulate changes without modifying the vault.
+var readOnlyPaths = []string{
+	"/auth/",
+	"/share/simulate/",
+}
+
+// readOnlyTransport is a http.RoundTripper refusing every request that could modify the vault.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// newReadOnlyTransport wraps the given transport, only letting reads through.
+func newReadOnlyTransport(next http.RoundTripper) *readOnlyTransport {
+	return &readOnlyTransport{
+		next: next,
+	}
+}
+
+// RoundTrip executes reads and fails any other request without sending it.
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+
+	for _, allowed := range readOnlyPaths {
+		if strings.Contains(req.URL.Path, allowed) {
+			return t.next.RoundTrip(req)
+		}
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("the provider is configured with read_only, refusing %s %s", req.Method, req.URL.Path)
+}
+
+// LoginRetryConfig describes how failed logins are retried.
+type LoginRetryConfig struct {
+	MaxAttempts int
+	Delay       time.Duration
+}
+
+// login logs in to Passbolt, retrying failed attempts after a fixed delay.
+func login(ctx context.Context, client *api.Client, config LoginRetryConfig) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = client.Login(ctx)
+		if err == nil || attempt >= config.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(config.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
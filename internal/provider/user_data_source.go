@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &UserDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserDataSource{}
+)
+
+// NewUserDataSource is a helper function to simplify the provider implementation.
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource is the data source implementation.
+type UserDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// UserDataSourceModel describes the data source data model.
+type UserDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	FirstName   types.String `tfsdk:"first_name"`
+	LastName    types.String `tfsdk:"last_name"`
+	Role        types.String `tfsdk:"role"`
+	Active      types.Bool   `tfsdk:"active"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *UserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *UserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique identifier of the user, either id or username must be set",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The username (email) of the user, either id or username must be set",
+			},
+			"first_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The first name of the user",
+			},
+			"last_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The last name of the user",
+			},
+			"role": schema.StringAttribute{
+				Computed:    true,
+				Description: "The role of the user",
+			},
+			"active": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the user completed the account setup",
+			},
+			"fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The fingerprint of the user's OpenPGP key, empty until the user completed the account setup",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_user", &resp.Diagnostics) {
+		return
+	}
+
+	var state UserDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.IsNull() && state.Username.IsNull() {
+		resp.Diagnostics.AddError("Validation Error", "Either id or username must be set")
+		return
+	}
+
+	// Resolve the user ID from the username if needed
+	userID := state.ID.ValueString()
+	if state.ID.IsNull() {
+		var err error
+		userID, err = getUserIDByUsername(ctx, d.client, state.Username.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading users",
+				"Could not read users, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		if userID == "" {
+			resp.Diagnostics.AddError("User not found", fmt.Sprintf("User '%s' not found", state.Username.ValueString()))
+			return
+		}
+	}
+
+	// Get the user with its key from Passbolt
+	user, err := d.client.GetUser(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading user",
+			"Could not read user, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	if !state.Username.IsNull() && user.Username != state.Username.ValueString() {
+		resp.Diagnostics.AddError(
+			"User not found",
+			fmt.Sprintf("User '%s' has username '%s', not '%s'", user.ID, user.Username, state.Username.ValueString()),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(user.ID)
+	state.Username = types.StringValue(user.Username)
+	state.FirstName = types.StringNull()
+	state.LastName = types.StringNull()
+	if user.Profile != nil {
+		state.FirstName = types.StringValue(user.Profile.FirstName)
+		state.LastName = types.StringValue(user.Profile.LastName)
+	}
+	state.Role = types.StringNull()
+	if user.Role != nil {
+		state.Role = types.StringValue(user.Role.Name)
+	}
+	state.Active = types.BoolValue(user.Active)
+	state.Fingerprint = types.StringValue(userFingerprint(user))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getUserIDByUsername returns the ID of the user with the given username, or an empty string if there is none.
+func getUserIDByUsername(ctx context.Context, client *api.Client, username string) (string, error) {
+	users, err := client.GetUsers(ctx, &api.GetUsersOptions{
+		FilterSearch: username,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The search filter also matches partial usernames and names
+	for _, user := range users {
+		if user.Username == username {
+			return user.ID, nil
+		}
+	}
+	return "", nil
+}
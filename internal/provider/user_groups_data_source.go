@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &UserGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserGroupsDataSource{}
+)
+
+// NewUserGroupsDataSource is a helper function to simplify the provider implementation.
+func NewUserGroupsDataSource() datasource.DataSource {
+	return &UserGroupsDataSource{}
+}
+
+// UserGroupsDataSource is the data source implementation.
+type UserGroupsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// UserGroupsDataSourceModel describes the data source data model.
+type UserGroupsDataSourceModel struct {
+	UserID   types.String           `tfsdk:"user_id"`
+	Username types.String           `tfsdk:"username"`
+	Groups   []UserGroupMemberModel `tfsdk:"groups"`
+}
+
+// getGroupsOptions are the query parameters of the groups index, api.GetGroupsOptions does not encode the users filter as the server expects.
+type getGroupsOptions struct {
+	FilterHasUsers     []string `url:"filter[has-users][],omitempty"`
+	ContainGroupsUsers bool     `url:"contain[groups_users],omitempty"`
+}
+
+// UserGroupMemberModel describes a single group membership of a user.
+type UserGroupMemberModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	IsManager types.Bool   `tfsdk:"is_manager"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *UserGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *UserGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *UserGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique identifier of the user, either user_id or username must be set",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The username (email) of the user, either user_id or username must be set",
+			},
+			"groups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The groups the user belongs to, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the group",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the group",
+						},
+						"is_manager": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the user is a manager of the group",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *UserGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_user_groups", &resp.Diagnostics) {
+		return
+	}
+
+	var state UserGroupsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.UserID.IsNull() && state.Username.IsNull() {
+		resp.Diagnostics.AddError("Validation Error", "Either user_id or username must be set")
+		return
+	}
+
+	// Resolve the user ID from the username if needed
+	userID := state.UserID.ValueString()
+	if state.UserID.IsNull() {
+		var err error
+		userID, err = getUserIDByUsername(ctx, d.client, state.Username.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading users",
+				"Could not read users, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		if userID == "" {
+			resp.Diagnostics.AddError("User not found", fmt.Sprintf("User '%s' not found", state.Username.ValueString()))
+			return
+		}
+	}
+
+	user, err := d.client.GetUser(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading user",
+			"Could not read user, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Let the server return only the groups of the user, with their memberships
+	groups, err := d.getGroups(ctx, getGroupsOptions{
+		FilterHasUsers:     []string{user.ID},
+		ContainGroupsUsers: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading groups",
+			"Could not read groups, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.Groups = make([]UserGroupMemberModel, 0)
+	for _, group := range groups {
+		for _, membership := range group.GroupUsers {
+			if membership.UserID == user.ID {
+				state.Groups = append(state.Groups, UserGroupMemberModel{
+					ID:        types.StringValue(group.ID),
+					Name:      types.StringValue(group.Name),
+					IsManager: types.BoolValue(membership.IsAdmin),
+				})
+				break
+			}
+		}
+	}
+	sort.SliceStable(state.Groups, func(i, j int) bool {
+		return state.Groups[i].Name.ValueString() < state.Groups[j].Name.ValueString()
+	})
+
+	state.UserID = types.StringValue(user.ID)
+	state.Username = types.StringValue(user.Username)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getGroups lists the groups matching the given options.
+func (d *UserGroupsDataSource) getGroups(ctx context.Context, opts getGroupsOptions) ([]api.Group, error) {
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/groups.json", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []api.Group
+	err = json.Unmarshal(msg.Body, &groups)
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
@@ -0,0 +1,434 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &UserResource{}
+	_ resource.ResourceWithConfigure   = &UserResource{}
+	_ resource.ResourceWithImportState = &UserResource{}
+	_ resource.ResourceWithModifyPlan  = &UserResource{}
+)
+
+// NewUserResource is a helper function to simplify the provider implementation.
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+// UserResource is the resource implementation.
+type UserResource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// UserResourceModel describes the resource data model.
+type UserResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Username     types.String   `tfsdk:"username"`
+	FirstName    types.String   `tfsdk:"first_name"`
+	LastName     types.String   `tfsdk:"last_name"`
+	Role         types.String   `tfsdk:"role"`
+	Fingerprint  types.String   `tfsdk:"fingerprint"`
+	ResendInvite types.String   `tfsdk:"resend_invite"`
+	TransferTo   types.String   `tfsdk:"transfer_to"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// recoverRequest is the body of the request re-sending the setup email of a user.
+type recoverRequest struct {
+	Username string `json:"username"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *UserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.data = data
+}
+
+// Metadata returns the resource type name.
+func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *UserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the user",
+			},
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "The username (email) of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"first_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The first name of the user",
+			},
+			"last_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The last name of the user",
+			},
+			"role": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     stringdefault.StaticString("user"),
+				Description: "The role of the user, either user or admin",
+			},
+			"fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The fingerprint of the user's OpenPGP key, empty until the user completed the account setup",
+			},
+			"transfer_to": schema.StringAttribute{
+				Optional: true,
+				Description: "The ID of the user receiving the ownership of the resources and folders, and the management of the groups, " +
+					"the user is the only owner or manager of when it is deleted. The receiving user must already have access to them",
+			},
+			"resend_invite": schema.StringAttribute{
+				Optional:    true,
+				Description: "Any change of this value re-sends the setup email while the user did not complete the account setup, such as a timestamp",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !requireConfigured(r.data, "passbolt_user", &resp.Diagnostics) {
+		return
+	}
+
+	var plan UserResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Validate input
+	if plan.Username.ValueString() == "" {
+		resp.Diagnostics.AddError("Validation Error", "Username cannot be empty")
+		return
+	}
+
+	// Create the user, Passbolt sends the invitation email
+	userID, err := helper.CreateUser(
+		ctx,
+		r.client,
+		plan.Role.ValueString(),
+		plan.Username.ValueString(),
+		plan.FirstName.ValueString(),
+		plan.LastName.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user",
+			"Could not create user, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Set the computed values, a new user has no key until the setup is completed
+	plan.ID = types.StringValue(userID)
+	plan.Fingerprint = types.StringValue("")
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !requireConfigured(r.data, "passbolt_user", &resp.Diagnostics) {
+		return
+	}
+
+	var state UserResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get the user from Passbolt
+	user, err := r.client.GetUser(ctx, state.ID.ValueString())
+	if err != nil && r.data.reauthenticate(ctx, err) {
+		user, err = r.client.GetUser(ctx, state.ID.ValueString())
+	}
+	if err != nil {
+		// Check if the user doesn't exist (was deleted outside of Terraform)
+		if isResourceNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading user",
+			"Could not read user, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Update the state with the current values from Passbolt
+	state.Username = types.StringValue(user.Username)
+	if user.Profile != nil {
+		state.FirstName = types.StringValue(user.Profile.FirstName)
+		state.LastName = types.StringValue(user.Profile.LastName)
+	}
+	if user.Role != nil {
+		state.Role = types.StringValue(user.Role.Name)
+	}
+	state.Fingerprint = types.StringValue(userFingerprint(user))
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !requireConfigured(r.data, "passbolt_user", &resp.Diagnostics) {
+		return
+	}
+
+	var plan UserResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state UserResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// The username forces a replacement, only carry over the computed values
+	plan.ID = state.ID
+	plan.Fingerprint = state.Fingerprint
+
+	// The profile and role are updated in place, empty values are left unchanged by the helper
+	if !plan.FirstName.Equal(state.FirstName) || !plan.LastName.Equal(state.LastName) || !plan.Role.Equal(state.Role) {
+		role := ""
+		if !plan.Role.Equal(state.Role) {
+			role = plan.Role.ValueString()
+		}
+
+		err := helper.UpdateUser(
+			ctx,
+			r.client,
+			state.ID.ValueString(),
+			role,
+			plan.FirstName.ValueString(),
+			plan.LastName.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not update user, unexpected error: "+describeError(err),
+			)
+			return
+		}
+	}
+
+	if !plan.ResendInvite.IsNull() && !plan.ResendInvite.Equal(state.ResendInvite) {
+		r.resendInvite(ctx, state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan fails at plan time when users would be changed without an administrator account.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if plansChange(req) {
+		requireAdmin(ctx, r.data, "passbolt_user", &resp.Diagnostics)
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !requireConfigured(r.data, "passbolt_user", &resp.Diagnostics) {
+		return
+	}
+
+	var state UserResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the user, Passbolt refuses to delete the only owner of resources unless their ownership is transferred
+	var err error
+	if state.TransferTo.IsNull() {
+		err = r.client.DeleteUser(ctx, state.ID.ValueString())
+	} else {
+		err = deleteWithTransfer(ctx, r.client, "/users/"+state.ID.ValueString(), state.TransferTo.ValueString())
+	}
+	if err != nil {
+		detail := "Could not delete user, unexpected error: " + describeError(err)
+		if state.TransferTo.IsNull() {
+			detail += "\n\nIf the user is the only owner of some resources or the only manager of some groups, set transfer_to and apply before destroying it."
+		}
+		resp.Diagnostics.AddError("Error deleting user", detail)
+		return
+	}
+}
+
+// ImportState imports an existing user by its ID or username (email).
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !requireConfigured(r.data, "passbolt_user", &resp.Diagnostics) {
+		return
+	}
+
+	userID := req.ID
+	if strings.Contains(req.ID, "@") {
+		var err error
+		userID, err = getUserIDByUsername(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing user",
+				"Could not read users, unexpected error: "+describeError(err),
+			)
+			return
+		}
+
+		if userID == "" {
+			resp.Diagnostics.AddError("User not found", fmt.Sprintf("User '%s' not found", req.ID))
+			return
+		}
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), resource.ImportStateRequest{ID: userID}, resp)
+}
+
+// resendInvite re-sends the setup email of a user who did not complete the account setup.
+func (r *UserResource) resendInvite(ctx context.Context, state UserResourceModel, diags *diag.Diagnostics) {
+	user, err := r.client.GetUser(ctx, state.ID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading user",
+			"Could not read user, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	if user.Active {
+		diags.AddWarning(
+			"Invite not re-sent",
+			fmt.Sprintf("User '%s' already completed the account setup, no invite was sent.", user.Username),
+		)
+		return
+	}
+
+	// Passbolt sends the setup email again when a pending user asks for recovery
+	_, err = doCustomRequest(ctx, r.client, "POST", "/users/recover.json", recoverRequest{Username: user.Username}, nil)
+	if err != nil {
+		diags.AddError(
+			"Error re-sending invite",
+			"Could not re-send the setup email, unexpected error: "+describeError(err),
+		)
+		return
+	}
+}
+
+// userFingerprint returns the fingerprint of the user's key, or an empty string if the user has no key yet.
+func userFingerprint(user *api.User) string {
+	if user.GPGKey == nil {
+		return ""
+	}
+	return user.GPGKey.Fingerprint
+}
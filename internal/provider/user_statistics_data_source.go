@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &UserStatisticsDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserStatisticsDataSource{}
+)
+
+// NewUserStatisticsDataSource is a helper function to simplify the provider implementation.
+func NewUserStatisticsDataSource() datasource.DataSource {
+	return &UserStatisticsDataSource{}
+}
+
+// UserStatisticsDataSource is the data source implementation.
+type UserStatisticsDataSource struct {
+	client *api.Client
+	data   *PassboltProviderData
+}
+
+// UserStatisticsDataSourceModel describes the data source data model.
+type UserStatisticsDataSourceModel struct {
+	Users         types.Int64 `tfsdk:"users"`
+	ActiveUsers   types.Int64 `tfsdk:"active_users"`
+	PendingUsers  types.Int64 `tfsdk:"pending_users"`
+	DisabledUsers types.Int64 `tfsdk:"disabled_users"`
+	Groups        types.Int64 `tfsdk:"groups"`
+}
+
+// userWithDisabled is a user including the time it was disabled, which api.User does not decode.
+type userWithDisabled struct {
+	api.User
+	Disabled *api.Time `json:"disabled,omitempty"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *UserStatisticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PassboltProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PassboltProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.data = data
+}
+
+// Metadata returns the data source type name.
+func (d *UserStatisticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_statistics"
+}
+
+// Schema defines the schema for the data source.
+func (d *UserStatisticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"users": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The total number of users",
+			},
+			"active_users": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of users that completed the account setup and are not disabled",
+			},
+			"pending_users": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of invited users that did not complete the account setup yet",
+			},
+			"disabled_users": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of disabled users",
+			},
+			"groups": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The total number of groups",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *UserStatisticsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !requireConfigured(d.data, "passbolt_user_statistics", &resp.Diagnostics) {
+		return
+	}
+
+	var state UserStatisticsDataSourceModel
+
+	// Get all users from Passbolt
+	msg, err := doCustomRequest(ctx, d.client, "GET", "/users.json", nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading users",
+			"Could not read users, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var users []userWithDisabled
+	err = json.Unmarshal(msg.Body, &users)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading users",
+			"Could not decode users, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	var active, pending, disabled int64
+	for _, user := range users {
+		switch {
+		case user.Disabled != nil:
+			disabled++
+		case user.Active:
+			active++
+		default:
+			pending++
+		}
+	}
+
+	// Get all groups from Passbolt
+	groups, err := d.data.Lookups.Groups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading groups",
+			"Could not read groups, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	state.Users = types.Int64Value(int64(len(users)))
+	state.ActiveUsers = types.Int64Value(active)
+	state.PendingUsers = types.Int64Value(pending)
+	state.DisabledUsers = types.Int64Value(disabled)
+	state.Groups = types.Int64Value(int64(len(groups)))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -50,6 +50,9 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Terraform is done with the provider, the unlocked private keys are not needed anymore
+	provider.ClearUnlockedKeys()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}